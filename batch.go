@@ -0,0 +1,166 @@
+package hackernews
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// maxConcurrency is the default number of requests made at once by the batch
+// methods
+const maxConcurrency = 8
+
+// BatchFind finds the stories for each id concurrently. It's the same as
+// FindMany, errors included.
+//
+// Deprecated: use FindMany.
+func (c *Client) BatchFind(ctx context.Context, ids []int) ([]*Story, error) {
+	return c.FindMany(ctx, ids)
+}
+
+// FindMany finds the stories for each id concurrently, at most 8 at a time
+// unless WithConcurrency says otherwise. Stories are returned in the same order
+// as the ids.
+//
+// FindMany returns partial results: stories that couldn't be found are nil, and
+// the ones that were found are returned with a *FindError that has the error
+// for each failed id. If the context is cancelled or its deadline passes before
+// every story was fetched, the error is the context's error (e.g.
+// context.DeadlineExceeded). Likewise, if the soft budget runs out, the
+// remaining stories are skipped and the error is ErrBudgetExceeded.
+func (c *Client) FindMany(ctx context.Context, ids []int) ([]*Story, error) {
+	stories := make([]*Story, len(ids))
+	var (
+		mu      sync.Mutex
+		failed  = map[int]error{}
+		skipped int32
+	)
+	exceeded := c.budgetExceeded()
+	c.concurrently(ctx, len(ids), func(i int) {
+		if exceeded() {
+			atomic.StoreInt32(&skipped, 1)
+			return
+		}
+		story, err := c.Find(ctx, ids[i])
+		if err != nil {
+			mu.Lock()
+			failed[ids[i]] = err
+			mu.Unlock()
+			return
+		}
+		stories[i] = story
+	})
+	if err := ctx.Err(); err != nil {
+		return stories, err
+	}
+	if atomic.LoadInt32(&skipped) == 1 {
+		return stories, ErrBudgetExceeded
+	}
+	if len(failed) > 0 {
+		return stories, &FindError{Errors: failed}
+	}
+	return stories, nil
+}
+
+// ResolveChildren finds each of the hit's children by id. It has the same
+// partial-result behavior as FindMany.
+func (c *Client) ResolveChildren(ctx context.Context, h *Hit) ([]*Story, error) {
+	return c.FindMany(ctx, h.Children)
+}
+
+// CommentCounts looks up the current number of comments on each story
+// concurrently, only asking Algolia for the comment count. Stories that can't
+// be found are left out of the map. When a lookup fails, the counts that were
+// found are returned along with the first error.
+func (c *Client) CommentCounts(ctx context.Context, ids []int) (map[int]int, error) {
+	counts := map[int]int{}
+	var (
+		mu       sync.Mutex
+		once     sync.Once
+		firstErr error
+	)
+	c.concurrently(ctx, len(ids), func(i int) {
+		result, err := c.searchStories(ctx, &SearchRequest{
+			Tags:                 "story,story_" + strconv.Itoa(ids[i]),
+			ResultsPerPage:       1,
+			AttributesToRetrieve: []string{"num_comments"},
+		})
+		if err != nil {
+			once.Do(func() { firstErr = err })
+			return
+		}
+		if len(result.Hits) == 0 {
+			return
+		}
+		count := 0
+		if result.Hits[0].NumComments != nil {
+			count = *result.Hits[0].NumComments
+		}
+		mu.Lock()
+		counts[ids[i]] = count
+		mu.Unlock()
+	})
+	if err := ctx.Err(); err != nil {
+		return counts, err
+	}
+	return counts, firstErr
+}
+
+// concurrently calls fn with each index from 0 to n, running at most the
+// client's concurrency at once. No more calls are started once the context is
+// done.
+func (c *Client) concurrently(ctx context.Context, n int, fn func(i int)) {
+	limit := c.concurrency
+	if limit <= 0 {
+		limit = maxConcurrency
+	}
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, limit)
+	)
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// FrontPageTopCommenters maps each story on the front page to the author of its
+// top comment (see Story.TopComment). Stories without comments are left out.
+// When some stories fail to load, the authors that were found are returned
+// along with the error.
+func (c *Client) FrontPageTopCommenters(ctx context.Context) (map[int]string, error) {
+	frontPage, err := c.FrontPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(frontPage))
+	for i, story := range frontPage {
+		ids[i] = story.ID
+	}
+	stories, err := c.FindMany(ctx, ids)
+	commenters := map[int]string{}
+	for _, story := range stories {
+		if story == nil {
+			continue
+		}
+		if top := story.TopComment(); top != nil && top.Author != nil {
+			commenters[story.ID] = *top.Author
+		}
+	}
+	return commenters, err
+}
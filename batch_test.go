@@ -0,0 +1,188 @@
+package hackernews_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+// itemHandler serves a minimal story for each item id, delaying the ones in
+// slow and failing the ones in missing
+func itemHandler(slow map[string]time.Duration, missing map[string]bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := path.Base(r.URL.Path)
+		if missing[id] {
+			http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		select {
+		case <-time.After(slow[id]):
+		case <-r.Context().Done():
+			return
+		}
+		fmt.Fprintf(w, `{"id":%s,"type":"story","title":"Story %s","children":[]}`, id, id)
+	})
+}
+
+func TestBatchFind(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, itemHandler(nil, map[string]bool{"2": true}))
+	//lint:ignore SA1019 BatchFind is kept working for existing callers
+	stories, err := hn.BatchFind(ctx, []int{1, 2, 3})
+	var findErr *hackernews.FindError
+	is.True(errors.As(err, &findErr))               // same errors as FindMany
+	is.True(errors.Is(err, hackernews.ErrNotFound)) // unwraps to the failure
+	is.Equal(stories[0].ID, 1)
+	is.Equal(stories[1], nil) // failed
+	is.Equal(stories[2].ID, 3)
+}
+
+func TestFindManyDeadline(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	hn := fakeClient(t, itemHandler(map[string]time.Duration{"2": time.Minute}, nil))
+	stories, err := hn.FindMany(ctx, []int{1, 2, 3})
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.Equal(len(stories), 3)
+	is.Equal(stories[0].ID, 1) // fetched before the deadline
+	is.Equal(stories[1], nil)  // didn't finish
+	is.Equal(stories[2].ID, 3) // fetched before the deadline
+}
+
+func TestCommentCounts(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	fixtures := map[string]string{
+		"story,story_1": `{"hits":[{"objectID":"1","num_comments":12}]}`,
+		"story,story_2": `{"hits":[{"objectID":"2"}]}`,
+		"story,story_3": `{"hits":[]}`,
+		"story,story_4": `{"hits":[{"objectID":"4","num_comments":0}]}`,
+	}
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("attributesToRetrieve") != "num_comments" {
+			t.Errorf("unexpected attributesToRetrieve %q", query.Get("attributesToRetrieve"))
+		}
+		fmt.Fprint(w, fixtures[query.Get("tags")])
+	}))
+	counts, err := hn.CommentCounts(ctx, []int{1, 2, 3, 4})
+	is.NoErr(err)
+	is.Equal(counts, map[int]int{1: 12, 2: 0, 4: 0}) // missing story is left out
+}
+
+func TestFrontPageTopCommenters(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/search":
+			is.Equal(r.URL.Query().Get("tags"), "front_page")
+			fmt.Fprint(w, `{"hits":[{"objectID":"100"},{"objectID":"200"},{"objectID":"300"}]}`)
+		case "/api/v1/items/100":
+			http.ServeFile(w, r, "testdata/item.json")
+		case "/api/v1/items/200":
+			fmt.Fprint(w, `{"id":200,"type":"story","children":[]}`)
+		case "/api/v1/items/300":
+			fmt.Fprint(w, `{"id":300,"type":"story","children":[
+				{"id":301,"author":"zed","text":"First!","children":[]}
+			]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	commenters, err := hn.FrontPageTopCommenters(ctx)
+	is.NoErr(err)
+	is.Equal(commenters, map[int]string{100: "bob", 300: "zed"}) // 200 has no comments
+}
+
+func TestResolveChildren(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/search":
+			fmt.Fprint(w, `{"hits":[{"objectID":"100","children":[102,101]}]}`)
+		case "/api/v1/items/101", "/api/v1/items/102":
+			id := path.Base(r.URL.Path)
+			fmt.Fprintf(w, `{"id":%s,"type":"comment","author":"bob","text":"Reply %s","parent_id":100,"story_id":100,"children":[]}`, id, id)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	result, err := hn.Search(ctx, &hackernews.SearchRequest{Query: "editor"})
+	is.NoErr(err)
+	children, err := hn.ResolveChildren(ctx, result.Hits[0])
+	is.NoErr(err)
+	is.Equal(len(children), 2)
+	is.Equal(children[0].ID, 102) // same order as the hit
+	is.Equal(*children[0].Text, "Reply 102")
+	is.Equal(children[1].ID, 101)
+	is.Equal(*children[1].ParentID, 100)
+}
+
+func TestFindMany(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, itemHandler(nil, nil))
+	ids := []int{5, 3, 9, 1, 12, 7, 2, 8, 4, 11}
+	stories, err := hn.FindMany(ctx, ids)
+	is.NoErr(err)
+	is.Equal(len(stories), len(ids))
+	for i, story := range stories {
+		is.Equal(story.ID, ids[i]) // same order as the ids
+	}
+}
+
+func TestFindManyPartial(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, itemHandler(nil, map[string]bool{"2": true, "4": true}))
+	stories, err := hn.FindMany(ctx, []int{1, 2, 3, 4})
+	var findErr *hackernews.FindError
+	is.True(errors.As(err, &findErr))
+	is.Equal(len(findErr.Errors), 2)
+	is.True(errors.Is(findErr.Errors[2], hackernews.ErrNotFound))
+	is.True(errors.Is(findErr.Errors[4], hackernews.ErrNotFound))
+	is.True(strings.HasPrefix(err.Error(), "hackernews: failed to find 2 items: 2: "))
+	is.Equal(stories[0].ID, 1)
+	is.Equal(stories[1], nil) // failed
+	is.Equal(stories[2].ID, 3)
+	is.Equal(stories[3], nil) // failed
+}
+
+func TestWithConcurrency(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var (
+		mu           sync.Mutex
+		active, peak int
+		handler      = itemHandler(nil, nil)
+	)
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		if active > peak {
+			peak = active
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		handler.ServeHTTP(w, r)
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}), hackernews.WithConcurrency(2))
+	_, err := hn.FindMany(ctx, []int{1, 2, 3, 4, 5, 6})
+	is.NoErr(err)
+	is.True(peak <= 2)
+}
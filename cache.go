@@ -0,0 +1,72 @@
+package hackernews
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// cache holds onto response bodies by URL until they expire. Expired entries
+// with an ETag are kept around so they can be revalidated.
+type cache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+func newCache(ttl time.Duration) *cache {
+	return &cache{
+		ttl:     ttl,
+		entries: map[string]cacheEntry{},
+		now:     time.Now,
+	}
+}
+
+// get the entry for the url and whether it's still fresh
+func (c *cache) get(url string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[url]
+	if !ok {
+		return nil, false
+	}
+	return &entry, c.now().Before(entry.expires)
+}
+
+// set the body for the url, dropping any entries that have expired and can't
+// be revalidated
+func (c *cache) set(url string, body []byte, etag string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for key, entry := range c.entries {
+		if entry.etag == "" && !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	if etag == "" && c.ttl <= 0 {
+		return
+	}
+	c.entries[url] = cacheEntry{body: body, etag: etag, expires: now.Add(c.ttl)}
+}
+
+type skipCacheKey struct{}
+
+// WithoutCache returns a context that makes calls skip the cache set up with
+// WithCache and go to the network. The fresh response is still cached for
+// later calls.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipCacheKey{}, true)
+}
+
+func skipCache(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipCacheKey{}).(bool)
+	return skip
+}
@@ -0,0 +1,194 @@
+package hackernews_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+// countingHandler counts the requests it gets before responding with a story
+func countingHandler(requests *int) http.Handler {
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*requests++
+		mu.Unlock()
+		fmt.Fprint(w, `{"id":1,"title":"Story","children":[],"hits":[{"objectID":"1"}]}`)
+	})
+}
+
+func TestWithCacheTransform(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	requests, transforms := 0, 0
+	var bodies []string
+	hn := fakeClient(t, countingHandler(&requests),
+		hackernews.WithCache(time.Minute),
+		hackernews.WithResponseTransform(func(body []byte) []byte {
+			transforms++
+			bodies = append(bodies, string(body))
+			return bytes.Replace(body, []byte("Story"), []byte("Patched"), 1)
+		}),
+	)
+	for i := 0; i < 2; i++ {
+		story, err := hn.Find(ctx, 1)
+		is.NoErr(err)
+		is.Equal(story.Title, "Patched")
+	}
+	is.Equal(requests, 1)   // the second call is cached
+	is.Equal(transforms, 2) // but transformed again
+	is.Equal(bodies[0], bodies[1])
+	is.True(strings.Contains(bodies[1], `"title":"Story"`)) // the cache kept the original body
+}
+
+func TestWithCache(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	requests := 0
+	hn := fakeClient(t, countingHandler(&requests), hackernews.WithCache(time.Minute))
+	story, err := hn.Find(ctx, 1)
+	is.NoErr(err)
+	story.Title = "Changed"
+	story, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(story.Title, "Story") // each call gets its own copy
+	_, err = hn.Search(ctx, &hackernews.SearchRequest{Query: "go"})
+	is.NoErr(err)
+	_, err = hn.Search(ctx, &hackernews.SearchRequest{Query: "go"})
+	is.NoErr(err)
+	is.Equal(requests, 2) // one per url
+	_, err = hn.Search(ctx, &hackernews.SearchRequest{Query: "rust"})
+	is.NoErr(err)
+	is.Equal(requests, 3) // different url
+}
+
+func TestWithCacheExpires(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	requests := 0
+	hn := fakeClient(t, countingHandler(&requests), hackernews.WithCache(20*time.Millisecond))
+	_, err := hn.Find(ctx, 1)
+	is.NoErr(err)
+	_, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(requests, 1)
+	time.Sleep(30 * time.Millisecond)
+	_, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(requests, 2) // refetched after the ttl
+}
+
+func TestWithoutCache(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	requests := 0
+	hn := fakeClient(t, countingHandler(&requests), hackernews.WithCache(time.Minute))
+	_, err := hn.Find(ctx, 1)
+	is.NoErr(err)
+	_, err = hn.Find(hackernews.WithoutCache(ctx), 1)
+	is.NoErr(err)
+	is.Equal(requests, 2) // skipped the cache
+	_, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(requests, 2)
+}
+
+func TestWithCacheErrors(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	calls := 0
+	hn := fakeClient(t, failingHandler(http.StatusInternalServerError, 1, &calls), hackernews.WithCache(time.Minute))
+	_, err := hn.Find(ctx, 1)
+	is.True(err != nil)
+	_, err = hn.Find(ctx, 1)
+	is.NoErr(err) // errors aren't cached
+	is.Equal(calls, 2)
+}
+
+func TestWithCacheConcurrent(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	requests := 0
+	hn := fakeClient(t, countingHandler(&requests), hackernews.WithCache(time.Minute))
+	stories, err := hn.FindMany(ctx, []int{1, 2, 3, 1, 2, 3, 1, 2, 3})
+	is.NoErr(err)
+	is.Equal(len(stories), 9)
+}
+
+// etagHandler serves a story with an ETag, responding 304 when the client
+// already has the current version
+func etagHandler(requests, notModified *int, etag *string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.Header.Get("If-None-Match") == *etag {
+			*notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", *etag)
+		fmt.Fprintf(w, `{"id":1,"title":"Version %s","children":[]}`, strings.Trim(*etag, `"`))
+	})
+}
+
+func TestWithETags(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	requests, notModified := 0, 0
+	etag := `"1"`
+	hn := fakeClient(t, etagHandler(&requests, &notModified, &etag), hackernews.WithETags())
+	story, err := hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(story.Title, "Version 1")
+	story, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(story.Title, "Version 1") // from the remembered body
+	is.Equal(requests, 2)
+	is.Equal(notModified, 1)
+	etag = `"2"`
+	story, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(story.Title, "Version 2") // changed on the server
+	is.Equal(notModified, 1)
+}
+
+func TestWithETagsAndCache(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	requests, notModified := 0, 0
+	etag := `"1"`
+	hn := fakeClient(t, etagHandler(&requests, &notModified, &etag), hackernews.WithETags(), hackernews.WithCache(20*time.Millisecond))
+	_, err := hn.Find(ctx, 1)
+	is.NoErr(err)
+	_, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(requests, 1) // fresh in the cache
+	time.Sleep(30 * time.Millisecond)
+	story, err := hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(story.Title, "Version 1")
+	is.Equal(requests, 2)
+	is.Equal(notModified, 1) // expired entry was revalidated
+}
+
+func TestWithETagsNoETag(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var inm []string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inm = append(inm, r.Header.Get("If-None-Match"))
+		fmt.Fprint(w, `{"id":1,"children":[]}`)
+	}), hackernews.WithETags())
+	for i := 0; i < 2; i++ {
+		_, err := hn.Find(ctx, 1)
+		is.NoErr(err)
+	}
+	is.Equal(inm, []string{"", ""}) // nothing to revalidate
+}
@@ -5,36 +5,121 @@
 package hackernews
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	_ "embed"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
-const baseURL = `http://hn.algolia.com/api/v1`
+const defaultBaseURL = `https://hn.algolia.com/api/v1`
+
+//go:embed Changelog.md
+var changelog string
+
+// version of the library, sent in the default User-Agent. It's read from the
+// latest release in the changelog, the same place `make release` reads it.
+var version = latestVersion(changelog)
+
+var defaultUserAgent = "hackernews-go/" + version
+
+// releaseHeading is a release in the changelog, like "# 0.7.0 / 2024-09-09"
+var releaseHeading = regexp.MustCompile(`(?m)^# ([0-9]+\.[0-9]+\.[0-9]+)`)
+
+// latestVersion finds the first release in the changelog. Unreleased changes
+// don't have a version yet, so they're skipped.
+func latestVersion(changelog string) string {
+	match := releaseHeading.FindStringSubmatch(changelog)
+	if match == nil {
+		return "dev"
+	}
+	return match[1]
+}
 
 // New HackerNews Client with defaults
-func New() *Client {
-	return &Client{http.DefaultClient}
+func New(options ...Option) *Client {
+	client := &Client{
+		Client:      http.DefaultClient,
+		baseURL:     defaultBaseURL,
+		firebaseURL: defaultFirebaseURL,
+		userAgent:   defaultUserAgent,
+	}
+	for _, option := range options {
+		option(client)
+	}
+	if client.etags && client.cache == nil {
+		// Without WithCache, every response is revalidated
+		client.cache = newCache(0)
+	}
+	return client
 }
 
 // Client for HackerNews. The HTTP Client can be overriden with your own.
 type Client struct {
 	*http.Client
+
+	baseURL        string
+	firebaseURL    string
+	headers        http.Header
+	findTimeout    time.Duration
+	searchTimeout  time.Duration
+	resultsPerPage int
+
+	decodeChildrenText bool
+	commentOrder       CommentOrder
+	keepDeleted        bool
+	referer            string
+	userAgent          string
+	transform          func([]byte) []byte
+	skipStories        bool
+	retryAttempts      int
+	retryDelay         time.Duration
+	softBudget         time.Duration
+	concurrency        int
+	cache              *cache
+	etags              bool
+	logger             func(*http.Request, *http.Response, error, time.Duration)
+
+	bytesMu    sync.Mutex
+	bytesRead  int64
+	byteBudget int64
+}
+
+// perPage is the number of results the convenience methods ask for
+func (c *Client) perPage() int {
+	if c.resultsPerPage > 0 {
+		return c.resultsPerPage
+	}
+	return 34
+}
+
+// withDefaults fills in the client's defaults, leaving the original request
+// untouched
+func (c *Client) withDefaults(search *SearchRequest) *SearchRequest {
+	if search.ResultsPerPage > 0 || c.resultsPerPage == 0 {
+		return search
+	}
+	withDefaults := *search
+	withDefaults.ResultsPerPage = c.resultsPerPage
+	return &withDefaults
 }
 
 // FrontPage is a convenience function for getting the results on
 // https://hackernews.com
 func (c *Client) FrontPage(ctx context.Context) ([]*Story, error) {
-	result, err := c.Search(ctx, &SearchRequest{
+	result, err := c.searchStories(ctx, &SearchRequest{
 		Tags:           "front_page",
-		ResultsPerPage: 34,
+		ResultsPerPage: c.perPage(),
 	})
 	if err != nil {
 		return nil, err
@@ -45,9 +130,9 @@ func (c *Client) FrontPage(ctx context.Context) ([]*Story, error) {
 // Newest is a convenience function for getting the results on
 // https://news.ycombinator.com/newest
 func (c *Client) Newest(ctx context.Context) ([]*Story, error) {
-	result, err := c.SearchRecent(ctx, &SearchRequest{
+	result, err := c.searchRecentStories(ctx, &SearchRequest{
 		Tags:           "story",
-		ResultsPerPage: 34,
+		ResultsPerPage: c.perPage(),
 	})
 	if err != nil {
 		return nil, err
@@ -58,9 +143,9 @@ func (c *Client) Newest(ctx context.Context) ([]*Story, error) {
 // AskHN is a convenience function for getting the results on
 // https://news.ycombinator.com/ask
 func (c *Client) AskHN(ctx context.Context) ([]*Story, error) {
-	result, err := c.SearchRecent(ctx, &SearchRequest{
+	result, err := c.searchRecentStories(ctx, &SearchRequest{
 		Tags:           "ask_hn",
-		ResultsPerPage: 34,
+		ResultsPerPage: c.perPage(),
 	})
 	if err != nil {
 		return nil, err
@@ -71,9 +156,9 @@ func (c *Client) AskHN(ctx context.Context) ([]*Story, error) {
 // ShowHN is a convenience function for getting the results on
 // https://news.ycombinator.com/show
 func (c *Client) ShowHN(ctx context.Context) ([]*Story, error) {
-	result, err := c.SearchRecent(ctx, &SearchRequest{
+	result, err := c.searchRecentStories(ctx, &SearchRequest{
 		Tags:           "show_hn",
-		ResultsPerPage: 34,
+		ResultsPerPage: c.perPage(),
 	})
 	if err != nil {
 		return nil, err
@@ -81,6 +166,71 @@ func (c *Client) ShowHN(ctx context.Context) ([]*Story, error) {
 	return result.Stories, nil
 }
 
+// bestWindow is how far back Best looks for stories
+const bestWindow = 3 * 24 * time.Hour
+
+// Best is a convenience function for getting something like the results on
+// https://news.ycombinator.com/best. Algolia doesn't have HN's ranking, so
+// these are the stories from the last three days with the most points.
+func (c *Client) Best(ctx context.Context) ([]*Story, error) {
+	result, err := c.searchStories(ctx, &SearchRequest{
+		Tags:           "story",
+		CreatedAfter:   time.Now().Add(-bestWindow),
+		ResultsPerPage: c.perPage(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	stories := result.Stories
+	sort.SliceStable(stories, func(i, j int) bool {
+		return stories[i].Points > stories[j].Points
+	})
+	return stories, nil
+}
+
+// NoComments is a convenience function for getting the newest items with the
+// given tag (e.g. "story" or "ask_hn") that haven't been commented on yet,
+// using the num_comments=0 filter. NumComments is either nil or zero. Items
+// that Algolia indexed without num_comments don't match the filter, so they're
+// left out.
+func (c *Client) NoComments(ctx context.Context, tag string) (*SearchResponse, error) {
+	if err := validTag(tag); err != nil {
+		return nil, err
+	}
+	result, err := c.searchRecentStories(ctx, &SearchRequest{
+		Tags:           tag,
+		NumComments:    "=0",
+		ResultsPerPage: c.perPage(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The filter can't bring back items without num_comments, but make sure
+	// nothing with comments slipped through
+	hits := result.Hits[:0]
+	stories := result.Stories[:0]
+	for i, story := range result.Stories {
+		if story.NumComments != nil && *story.NumComments > 0 {
+			continue
+		}
+		hits = append(hits, result.Hits[i])
+		stories = append(stories, story)
+	}
+	result.Hits = hits
+	result.Stories = stories
+	return result, nil
+}
+
+// Ping checks that the API is reachable by making the cheapest possible search.
+// It returns an *APIError when the API responds with an unexpected status.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.searchStories(ctx, &SearchRequest{
+		ResultsPerPage: 1,
+		ResponseFields: []string{"nbHits"},
+	})
+	return err
+}
+
 // Story is an individual entry on HackerNews.
 type Story struct {
 	ID          int        `json:"id,omitempty"`
@@ -96,6 +246,36 @@ type Story struct {
 	ParentID    *int       `json:"parent_id,omitempty"`
 	StoryID     *int       `json:"story_id,omitempty"`
 	Children    []Children `json:"children"`
+
+	// RelevancyScore is Algolia's relevancy score for stories returned by a
+	// search. It's nil for stories returned by Find.
+	RelevancyScore *int `json:"relevancy_score,omitempty"`
+
+	// Tags are Algolia's tags for the search result (e.g. "story",
+	// "author_pg"). They're nil for stories returned by Find.
+	Tags []string `json:"_tags,omitempty"`
+
+	// StoryTitle and StoryURL are the title and URL of the story that a comment
+	// returned by a search belongs to.
+	StoryTitle *string `json:"story_title,omitempty"`
+	StoryURL   *string `json:"story_url,omitempty"`
+}
+
+// createdAt prefers the unix timestamp since it's always present, falling back
+// to the parsed created_at date.
+func (s *Story) createdAt() time.Time {
+	if s.CreatedAtI > 0 {
+		return time.Unix(int64(s.CreatedAtI), 0).UTC()
+	}
+	return s.CreatedAt
+}
+
+// createdAt is the comment's creation time. See Story.createdAt.
+func (c *Children) createdAt() time.Time {
+	if c.CreatedAtI > 0 {
+		return time.Unix(int64(c.CreatedAtI), 0).UTC()
+	}
+	return c.CreatedAt
 }
 
 // Children are the comments.
@@ -112,57 +292,399 @@ type Children struct {
 	ParentID   int        `json:"parent_id,omitempty"`
 	StoryID    int        `json:"story_id,omitempty"`
 	Children   []Children `json:"children"`
+
+	// Deleted is true for comments that were removed or killed. They're only
+	// kept when the client was created with WithDeletedComments.
+	Deleted bool `json:"deleted,omitempty"`
 }
 
 // Find a Story by its id.
 func (c *Client) Find(ctx context.Context, id int) (*Story, error) {
-	url := fmt.Sprintf("%s/items/%d", baseURL, id)
+	story, err := c.fetchItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	story.Children = c.processChildren(story.Children)
+	return story, nil
+}
+
+// Comments finds the comment tree of an item, filtered and sorted the same way
+// as Find.
+func (c *Client) Comments(ctx context.Context, id int) ([]Children, error) {
+	story, err := c.fetchItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.processChildren(story.Children), nil
+}
+
+// processChildren filters, sorts and decodes the comment tree
+func (c *Client) processChildren(children []Children) []Children {
+	if c.keepDeleted {
+		markDeleted(children)
+	} else {
+		children = filterChildren(children)
+	}
+	sortChildren(children, c.commentOrder)
+	if c.decodeChildrenText {
+		decodeChildrenText(children)
+	}
+	return children
+}
+
+// DirectReplyCount returns the number of top-level replies to an item. Unlike
+// Find, the comment tree isn't filtered or sorted recursively.
+func (c *Client) DirectReplyCount(ctx context.Context, id int) (int, error) {
+	story, err := c.fetchItem(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, child := range story.Children {
+		if child.Author == nil || child.Text == nil {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// fetchItem fetches the raw item without any processing
+func (c *Client) fetchItem(ctx context.Context, id int) (*Story, error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.findTimeout)
+	defer cancel()
+	story := new(Story)
+	if err := c.get(ctx, fmt.Sprintf("%s/items/%d", c.baseURL, id), story); err != nil {
+		return nil, err
+	}
+	return story, nil
+}
+
+// get the Algolia url and decode the JSON response into v
+func (c *Client) get(ctx context.Context, url string, v interface{}) error {
+	return c.getWithHeaders(ctx, url, c.headers, v)
+}
+
+// getWithHeaders gets the url with the extra headers and decodes the JSON
+// response into v
+func (c *Client) getWithHeaders(ctx context.Context, url string, headers http.Header, v interface{}) error {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
+		return err
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.referer != "" {
+		req.Header.Set("Referer", c.referer)
+	}
+	for key, values := range headers {
+		req.Header[key] = values
+	}
+	var cached *cacheEntry
+	if c.cache != nil {
+		if entry, fresh := c.cache.get(url); fresh && !skipCache(ctx) {
+			return c.decode(entry.body, v)
+		} else if entry != nil && entry.etag != "" {
+			// Ask the server to skip the body if it hasn't changed
+			cached = entry
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+	}
+	res, err := c.retry(ctx, req, v)
+	if err != nil {
+		return err
+	}
+	if res.decoded {
+		return nil
+	}
+	body := res.body
+	if res.status == http.StatusNotModified {
+		if cached == nil {
+			return &APIError{StatusCode: res.status, Body: string(body)}
+		}
+		body = cached.body
+	}
+	if err := c.decode(body, v); err != nil {
+		return err
+	}
+	if c.cache != nil {
+		etag := ""
+		if c.etags {
+			etag = res.header.Get("ETag")
+		}
+		c.cache.set(url, body, etag)
+	}
+	return nil
+}
+
+// decode the response body into v
+func (c *Client) decode(body []byte, v interface{}) error {
+	if c.transform != nil {
+		body = c.transform(body)
+	}
+	if isHTML(body) {
+		return htmlError(body)
+	}
+	return json.Unmarshal(body, v)
+}
+
+// Some CDNs respond with an HTML error page and a 200 when the API is down
+func isHTML(body []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(body), []byte("<"))
+}
+
+func htmlError(body []byte) error {
+	return fmt.Errorf("unexpected HTML response with status %d: %s", http.StatusOK, snippet(body))
+}
+
+// streams reports whether responses can be decoded straight off the wire.
+// Transforms, the cache and the logger all need the whole body.
+func (c *Client) streams() bool {
+	return c.transform == nil && c.cache == nil && c.logger == nil
+}
+
+// decodeStream decodes the body into v as it's read, rather than reading it
+// into memory first. Errors that aren't from reading the body are returned as
+// a *decodeError so they aren't retried.
+func decodeStream(body io.Reader, v interface{}) error {
+	er := &errReader{r: body}
+	br := bufio.NewReader(er)
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			break // let the decoder report it
+		}
+		if b == ' ' || b == '\t' || b == '\r' || b == '\n' {
+			continue
+		}
+		br.UnreadByte()
+		if b == '<' {
+			head, _ := br.Peek(maxSnippet + 1)
+			return &decodeError{htmlError(head)}
+		}
+		break
+	}
+	if err := json.NewDecoder(br).Decode(v); err != nil {
+		if er.err != nil && err == er.err {
+			return err
+		}
+		return &decodeError{err}
+	}
+	return nil
+}
+
+// errReader remembers the last error from reading, so network errors can be
+// told apart from invalid JSON
+type errReader struct {
+	r   io.Reader
+	err error
+}
+
+func (er *errReader) Read(p []byte) (int, error) {
+	n, err := er.r.Read(p)
+	if err != nil && err != io.EOF {
+		er.err = err
+	}
+	return n, err
+}
+
+// decodeError is a response that was received but couldn't be decoded
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string { return e.err.Error() }
+func (e *decodeError) Unwrap() error { return e.err }
+
+// response is what's left of a successful HTTP response once it's been read
+type response struct {
+	status int
+	header http.Header
+	body   []byte
+
+	// decoded is true when the body was decoded straight into v, in which
+	// case body is empty
+	decoded bool
+}
+
+// maxErrorBody is the most of an error response's body that's read
+const maxErrorBody = 64 << 10
+
+// maxDrain is the most that's read from a response after v was decoded, so the
+// connection can be reused
+const maxDrain = 4 << 10
+
+// do sends a single request and reads the response body. When possible, the
+// body of a 200 is decoded straight into v. Responses other than 200 and 304
+// Not Modified return an *APIError with the first maxErrorBody bytes of the
+// body.
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*response, error) {
+	if err := c.checkByteBudget(); err != nil {
 		return nil, err
 	}
+	start := time.Now()
 	res, err := c.Client.Do(req.WithContext(ctx))
 	if err != nil {
+		c.log(req, nil, err, time.Since(start))
 		return nil, err
 	}
 	defer res.Body.Close()
-	body, err := io.ReadAll(res.Body)
+	reader := io.Reader(&countingReader{res.Body, c})
+	if res.StatusCode == http.StatusOK && v != nil && c.streams() {
+		err := decodeStream(reader, v)
+		io.Copy(io.Discard, io.LimitReader(reader, maxDrain))
+		if err != nil {
+			return nil, err
+		}
+		if err := c.checkByteBudget(); err != nil {
+			return nil, err
+		}
+		return &response{status: res.StatusCode, header: res.Header, decoded: true}, nil
+	}
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotModified {
+		reader = io.LimitReader(reader, maxErrorBody)
+	}
+	body, err := io.ReadAll(reader)
+	// Let the logger read the body without affecting anything else
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	c.log(req, res, err, time.Since(start))
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("unexpected status %d: %s", res.StatusCode, string(body))
-	}
-	story := new(Story)
-	if err := json.Unmarshal(body, story); err != nil {
+	if err := c.checkByteBudget(); err != nil {
 		return nil, err
 	}
-	story.Children = filterChildren(story.Children)
-	recursivelySort(story.Children)
-	return story, nil
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotModified {
+		return nil, &APIError{
+			StatusCode: res.StatusCode,
+			Body:       string(body),
+			RetryAfter: retryAfter(res.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+	return &response{status: res.StatusCode, header: res.Header, body: body}, nil
 }
 
-// Some comments are nil for some reason (perhaps removed?)
-func filterChildren(childs []Children) (children []Children) {
+// log the request with the logger from WithLogger, if there is one
+func (c *Client) log(req *http.Request, res *http.Response, err error, duration time.Duration) {
+	if c.logger != nil {
+		c.logger(req, res, err, duration)
+	}
+}
+
+// maxSnippet is the most of a body that's included in an error message
+const maxSnippet = 200
+
+// snippet shortens the body for use in an error message
+func snippet(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) <= maxSnippet {
+		return string(body)
+	}
+	return string(body[:maxSnippet]) + "..."
+}
+
+// Some comments are nil for some reason (perhaps removed?). The tree is walked
+// with an explicit stack so deep threads don't grow the call stack.
+func filterChildren(childs []Children) []Children {
+	children := keepComments(childs)
+	stack := [][]Children{children}
+	for len(stack) > 0 {
+		level := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for i := range level {
+			level[i].Children = keepComments(level[i].Children)
+			stack = append(stack, level[i].Children)
+		}
+	}
+	return children
+}
+
+// keepComments copies the comments that have both an author and text
+func keepComments(childs []Children) (children []Children) {
 	for _, child := range childs {
 		if child.Author == nil || child.Text == nil {
 			continue
 		}
-		child.Children = filterChildren(child.Children)
 		children = append(children, child)
 	}
 	return children
 }
 
-func recursivelySort(children []Children) {
-	sort.Slice(children, func(a, b int) bool {
-		return children[a].CreatedAtI < children[b].CreatedAtI
-	})
-	for _, child := range children {
-		recursivelySort(child.Children)
+// markDeleted flags the comments that filterChildren would have removed
+func markDeleted(children []Children) {
+	stack := [][]Children{children}
+	for len(stack) > 0 {
+		level := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for i := range level {
+			level[i].Deleted = level[i].Author == nil || level[i].Text == nil
+			stack = append(stack, level[i].Children)
+		}
+	}
+}
+
+// CommentOrder is the order that Find sorts comments in at every level of the
+// tree
+type CommentOrder int
+
+const (
+	// OldestFirst sorts comments by when they were created. It's the default.
+	OldestFirst CommentOrder = iota
+	// MostPoints sorts comments by points, highest first, with ties going to
+	// the older comment. Comments without points count as zero.
+	MostPoints
+)
+
+// Sorts the comments in the given order, breaking ties by creation time and
+// then ID so the order is deterministic
+func sortChildren(children []Children, order CommentOrder) {
+	stack := [][]Children{children}
+	for len(stack) > 0 {
+		level := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		sort.Slice(level, func(a, b int) bool {
+			if order == MostPoints {
+				if pa, pb := commentPoints(&level[a]), commentPoints(&level[b]); pa != pb {
+					return pa > pb
+				}
+			}
+			if level[a].CreatedAtI != level[b].CreatedAtI {
+				return level[a].CreatedAtI < level[b].CreatedAtI
+			}
+			return level[a].ID < level[b].ID
+		})
+		for i := range level {
+			stack = append(stack, level[i].Children)
+		}
 	}
 }
 
+func commentPoints(c *Children) int {
+	if c.Points == nil {
+		return 0
+	}
+	return *c.Points
+}
+
+// The attributes that SearchRequest.SearchIn can restrict the query to
+const (
+	AttributeTitle       = "title"
+	AttributeURL         = "url"
+	AttributeAuthor      = "author"
+	AttributeStoryText   = "story_text"
+	AttributeCommentText = "comment_text"
+)
+
+// searchableAttributes are the attributes Algolia can search in
+var searchableAttributes = map[string]bool{
+	AttributeTitle:       true,
+	AttributeURL:         true,
+	AttributeAuthor:      true,
+	AttributeStoryText:   true,
+	AttributeCommentText: true,
+}
+
 // SearchRequest query and filters
 type SearchRequest struct {
 	// Full-text query to search for (e.g. Duo)
@@ -185,6 +707,10 @@ type SearchRequest struct {
 	// `author_pg,(story,poll)` filters on `author=pg AND (type=story OR type=poll)`
 	Tags string
 
+	// SearchIn restricts the query to the given attributes, like AttributeTitle
+	// for title-only searches. Defaults to every searchable attribute.
+	SearchIn []string
+
 	// Filter by points. Points is a conditional query, so you can request stories
 	// that have more than 500 points with "points > 500".
 	Points string
@@ -198,11 +724,126 @@ type SearchRequest struct {
 	// can request stories that have more than 10 comments with "comments > 10".
 	NumComments string
 
-	// The page number
+	// CreatedAfter and CreatedBefore only match results created strictly after
+	// or before the given times, rounded down to the second. Zero times are
+	// ignored. They're ANDed with CreatedAt.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// Filters are typed numeric filters (e.g. PointsAtLeast(100)). They're
+	// ANDed with each other and with Points, CreatedAt and NumComments.
+	Filters []NumericFilter
+
+	// Page is the page of results to get. Pages start at 1 for both Search
+	// and SearchRecent, and 0 also means the first page. SearchResponse.Page
+	// counts the same way.
 	Page int
 
-	// ResultsPerPage is the number of results. Defaults to 34.
+	// ResultsPerPage is the number of results, up to 1000. Defaults to 34.
 	ResultsPerPage int
+
+	// ResponseFields trims the response down to the given fields (e.g. "hits",
+	// "nbPages"). Fields of SearchResponse that aren't requested are left at
+	// their zero value: "hits" populates Hits and Stories, "nbHits" NumResults,
+	// "page" Page, "nbPages" NumPages, "hitsPerPage" ResultsPerPage,
+	// "exhaustiveNbHits" ExhaustiveNumResults, "query" Query, "params" Params
+	// and "processingTimeMS" ProcessingTimeMS. Defaults to every field.
+	ResponseFields []string
+
+	// AttributesToRetrieve limits the attributes returned for each hit (e.g.
+	// "title", "num_comments"). Defaults to every attribute.
+	AttributesToRetrieve []string
+
+	// RankingInfo asks Algolia to explain how each hit was ranked. The details
+	// are available on Hit.RankingInfo.
+	RankingInfo bool
+
+	// RemoveWordsIfNoResults tells Algolia which words to drop from the query
+	// when it doesn't match anything: "none" (the default), "lastWords",
+	// "firstWords" or "allOptional". The query that was used ends up in
+	// SearchResponse.QueryAfterRemoval.
+	RemoveWordsIfNoResults string
+
+	// TypoTolerance controls how Algolia matches misspelled words: "true" (the
+	// default), "false", "min" to only keep the hits with the fewest typos or
+	// "strict" to also rank exact matches first.
+	TypoTolerance string
+
+	// AdvancedSyntax enables "exact phrase" quoting and -word exclusion in
+	// Query. Defaults to false.
+	AdvancedSyntax bool
+
+	// OptionalWords are words in Query that hits don't have to match, though
+	// hits that do match them rank higher. Defaults to none.
+	OptionalWords []string
+
+	// MaxPages caps how many pages SearchAll walks through. It isn't sent to
+	// Algolia. Defaults to every page.
+	MaxPages int
+}
+
+// EncodeQuery encodes the search request as a URL query string. Use
+// ParseSearchRequest to turn it back into a search request.
+func (s *SearchRequest) EncodeQuery() string {
+	return s.querystring()
+}
+
+// ParseSearchRequest parses a URL query string created by EncodeQuery back into
+// a search request.
+func ParseSearchRequest(query string) (*SearchRequest, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(query, "?"))
+	if err != nil {
+		return nil, err
+	}
+	search := &SearchRequest{
+		Query: values.Get("query"),
+		Tags:  values.Get("tags"),
+	}
+	if page := values.Get("page"); page != "" {
+		if search.Page, err = strconv.Atoi(page); err != nil {
+			return nil, fmt.Errorf("invalid page %q: %w", page, err)
+		}
+	}
+	if perPage := values.Get("hitsPerPage"); perPage != "" {
+		if search.ResultsPerPage, err = strconv.Atoi(perPage); err != nil {
+			return nil, fmt.Errorf("invalid hitsPerPage %q: %w", perPage, err)
+		}
+	}
+	if attributes := values.Get("restrictSearchableAttributes"); attributes != "" {
+		search.SearchIn = strings.Split(attributes, ",")
+	}
+	if fields := values.Get("responseFields"); fields != "" {
+		search.ResponseFields = strings.Split(fields, ",")
+	}
+	if attributes := values.Get("attributesToRetrieve"); attributes != "" {
+		search.AttributesToRetrieve = strings.Split(attributes, ",")
+	}
+	search.RankingInfo = values.Get("getRankingInfo") == "true"
+	search.RemoveWordsIfNoResults = values.Get("removeWordsIfNoResults")
+	search.TypoTolerance = values.Get("typoTolerance")
+	search.AdvancedSyntax = values.Get("advancedSyntax") == "true"
+	if words := values.Get("optionalWords"); words != "" {
+		search.OptionalWords = strings.Split(words, ",")
+	}
+	if filters := values.Get("numericFilters"); filters != "" {
+		var points, createdAt, numComments []string
+		for _, filter := range strings.Split(filters, ",") {
+			switch {
+			case strings.HasPrefix(filter, "points"):
+				points = append(points, filter)
+			case strings.HasPrefix(filter, "created_at_i"):
+				createdAt = append(createdAt, filter)
+			case strings.HasPrefix(filter, "num_comments"):
+				numComments = append(numComments, filter)
+			default:
+				return nil, fmt.Errorf("unsupported numeric filter %q", filter)
+			}
+		}
+		search.Points = strings.Join(points, ",")
+		search.CreatedAt = strings.Join(createdAt, ",")
+		search.NumComments = strings.Join(numComments, ",")
+	}
+	return search, nil
 }
 
 // Turns the search input into a query string.
@@ -214,6 +855,9 @@ func (s *SearchRequest) querystring() string {
 	if s.Tags != "" {
 		query.Set("tags", s.Tags)
 	}
+	if len(s.SearchIn) > 0 {
+		query.Set("restrictSearchableAttributes", strings.Join(s.SearchIn, ","))
+	}
 	if s.Page > 0 {
 		query.Set("page", strconv.Itoa(s.Page))
 	}
@@ -224,9 +868,18 @@ func (s *SearchRequest) querystring() string {
 	if s.CreatedAt != "" {
 		nfs = append(nfs, injectKey(s.CreatedAt, "created_at_i"))
 	}
+	if !s.CreatedAfter.IsZero() {
+		nfs = append(nfs, string(numericFilter("created_at_i", ">", s.CreatedAfter.Unix())))
+	}
+	if !s.CreatedBefore.IsZero() {
+		nfs = append(nfs, string(numericFilter("created_at_i", "<", s.CreatedBefore.Unix())))
+	}
 	if s.NumComments != "" {
 		nfs = append(nfs, injectKey(s.NumComments, "num_comments"))
 	}
+	for _, filter := range s.Filters {
+		nfs = append(nfs, string(filter))
+	}
 	if len(nfs) > 0 {
 		query.Set("numericFilters", strings.Join(nfs, ","))
 	}
@@ -234,15 +887,38 @@ func (s *SearchRequest) querystring() string {
 	if s.ResultsPerPage > 0 {
 		query.Set("hitsPerPage", strconv.Itoa(s.ResultsPerPage))
 	}
+	if len(s.ResponseFields) > 0 {
+		query.Set("responseFields", strings.Join(s.ResponseFields, ","))
+	}
+	if len(s.AttributesToRetrieve) > 0 {
+		query.Set("attributesToRetrieve", strings.Join(s.AttributesToRetrieve, ","))
+	}
+	if s.RankingInfo {
+		query.Set("getRankingInfo", "true")
+	}
+	if s.RemoveWordsIfNoResults != "" {
+		query.Set("removeWordsIfNoResults", s.RemoveWordsIfNoResults)
+	}
+	if s.TypoTolerance != "" {
+		query.Set("typoTolerance", s.TypoTolerance)
+	}
+	if s.AdvancedSyntax {
+		query.Set("advancedSyntax", "true")
+	}
+	if len(s.OptionalWords) > 0 {
+		query.Set("optionalWords", strings.Join(s.OptionalWords, ","))
+	}
 	return query.Encode()
 }
 
 // Sugar on top to allow bot "points > 500" and "> 500", to reduce repetition
-// with the key (e.g. Points: "points > 500")
+// with the key (e.g. Points: "points > 500"). Whitespace is removed and "==" is
+// treated as Algolia's "=" operator, so "= 1609459200" works too.
 func injectKey(query, key string) string {
 	parts := strings.Split(query, ",")
 	for i, part := range parts {
-		parts[i] = strings.TrimSpace(part)
+		parts[i] = strings.Join(strings.Fields(part), "")
+		parts[i] = strings.Replace(parts[i], "==", "=", 1)
 		if !strings.HasPrefix(parts[i], key) {
 			parts[i] = key + parts[i]
 		}
@@ -262,6 +938,19 @@ type SearchResponse struct {
 	Query                string   `json:"query,omitempty"`
 	Params               string   `json:"params,omitempty"`
 	ProcessingTimeMS     int      `json:"processingTimeMS,omitempty"`
+
+	// QueryAfterRemoval is the query Algolia ended up using after removing
+	// words, with the removed words wrapped in <em> tags. It's only set when
+	// RemoveWordsIfNoResults kicked in.
+	QueryAfterRemoval string `json:"queryAfterRemoval,omitempty"`
+
+	// Suggestion is QueryAfterRemoval without the removed words, which makes
+	// it a suggested alternative to a query with few or no results.
+	Suggestion string `json:"suggestion,omitempty"`
+
+	// RequestURL is the URL the client requested, including the encoded query
+	// string, for debugging and reproducing the search.
+	RequestURL string `json:"requestURL,omitempty"`
 }
 
 func toStories(s *SearchResponse) ([]*Story, error) {
@@ -272,23 +961,35 @@ func toStories(s *SearchResponse) ([]*Story, error) {
 			return nil, err
 		}
 		stories[i] = &Story{
-			Author:      story.Author,
-			Children:    []Children{},
-			CreatedAt:   story.CreatedAt,
-			CreatedAtI:  story.CreatedAtI,
-			ID:          id,
-			NumComments: story.NumComments,
-			ParentID:    story.ParentID,
-			Points:      story.Points,
-			StoryID:     story.StoryID,
-			Title:       story.Title,
-			Text:        nil,
-			URL:         story.URL,
+			Author:         story.Author,
+			Children:       []Children{},
+			CreatedAt:      story.CreatedAt,
+			CreatedAtI:     story.CreatedAtI,
+			ID:             id,
+			NumComments:    story.NumComments,
+			ParentID:       story.ParentID,
+			Points:         story.Points,
+			StoryID:        story.StoryID,
+			Title:          story.Title,
+			Text:           hitText(story),
+			URL:            story.URL,
+			RelevancyScore: story.RelevancyScore,
+			Tags:           story.Tags,
+			StoryTitle:     story.StoryTitle,
+			StoryURL:       story.StoryURL,
 		}
 	}
 	return stories, nil
 }
 
+// hitText is the body of a self-post or the text of a comment
+func hitText(h *Hit) *string {
+	if h.StoryText != nil {
+		return h.StoryText
+	}
+	return h.CommentText
+}
+
 // Hit is an individual search result (story or comment)
 type Hit struct {
 	ID             string    `json:"objectID,omitempty"`
@@ -308,12 +1009,16 @@ type Hit struct {
 	RelevancyScore *int      `json:"relevancy_score,omitempty"`
 	Tags           []string  `json:"_tags,omitempty"`
 	Highlights     struct {
-		Title     Highlight `json:"title,omitempty"`
-		URL       Highlight `json:"url,omitempty"`
-		Author    Highlight `json:"author,omitempty"`
-		StoryText Highlight `json:"story_text,omitempty"`
+		Title       Highlight `json:"title,omitempty"`
+		URL         Highlight `json:"url,omitempty"`
+		Author      Highlight `json:"author,omitempty"`
+		StoryText   Highlight `json:"story_text,omitempty"`
+		CommentText Highlight `json:"comment_text,omitempty"`
+		StoryTitle  Highlight `json:"story_title,omitempty"`
+		StoryURL    Highlight `json:"story_url,omitempty"`
 	} `json:"_highlightResult,omitempty"`
-	Children []int `json:"children"`
+	Children    []int        `json:"children"`
+	RankingInfo *RankingInfo `json:"_rankingInfo,omitempty"`
 }
 
 // Highlight indicates the words that matched the search query
@@ -324,63 +1029,73 @@ type Highlight struct {
 }
 
 // Search for Stories. Sorted by relevance, then points, then number of comments.
+// Results are kept in the order Algolia returns them, the client never
+// reorders them. Pages start at 1, see SearchRequest.Page.
 func (c *Client) Search(ctx context.Context, search *SearchRequest) (*SearchResponse, error) {
-	if search.Page >= 1 {
-		search.Page = search.Page - 1
-	}
-	url := baseURL + "/search?" + search.querystring()
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	res, err := c.Client.Do(req.WithContext(ctx))
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
+	return c.search(ctx, "/search", search, !c.skipStories)
+}
+
+// Search for Stories. Sorted by date, more recent first. Pages start at 1, see
+// SearchRequest.Page.
+func (c *Client) SearchRecent(ctx context.Context, search *SearchRequest) (*SearchResponse, error) {
+	return c.search(ctx, "/search_by_date", search, !c.skipStories)
+}
+
+// searchStories is Search for the methods built on top of it, which always
+// need the stories regardless of WithPopulateStories
+func (c *Client) searchStories(ctx context.Context, search *SearchRequest) (*SearchResponse, error) {
+	return c.search(ctx, "/search", search, true)
+}
+
+// searchRecentStories is SearchRecent for the methods built on top of it
+func (c *Client) searchRecentStories(ctx context.Context, search *SearchRequest) (*SearchResponse, error) {
+	return c.search(ctx, "/search_by_date", search, true)
+}
+
+// search gets a page of results from the endpoint. Algolia's pages start at 0,
+// so the page is shifted on the way in and out.
+func (c *Client) search(ctx context.Context, endpoint string, search *SearchRequest, convert bool) (*SearchResponse, error) {
+	if err := search.Validate(); err != nil {
 		return nil, err
 	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("unexpected status %d: %s", res.StatusCode, string(body))
+	ctx, cancel := withDefaultTimeout(ctx, c.searchTimeout)
+	defer cancel()
+	algolia := *c.withDefaults(search)
+	if algolia.Page > 0 {
+		algolia.Page--
 	}
+	requestURL := c.baseURL + endpoint + "?" + algolia.querystring()
 	result := new(SearchResponse)
-	if err := json.Unmarshal(body, result); err != nil {
+	if err := c.get(ctx, requestURL, result); err != nil {
 		return nil, err
 	}
-	result.Page++
-	// Convert the hits to stories
-	stories, err := toStories(result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert hits to stories: %w", err)
+	result.RequestURL = requestURL
+	if respondsWith(algolia.ResponseFields, "page") {
+		result.Page++
 	}
-	result.Stories = stories
-	return result, nil
+	return populate(result, convert)
 }
 
-// Search for Stories. Sorted by date, more recent first.
-func (c *Client) SearchRecent(ctx context.Context, search *SearchRequest) (*SearchResponse, error) {
-	url := baseURL + "/search_by_date?" + search.querystring()
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	res, err := c.Client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
+// respondsWith reports whether the response includes the field, given the
+// fields that were asked for. Every field is included by default.
+func respondsWith(fields []string, field string) bool {
+	if len(fields) == 0 {
+		return true
 	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("unexpected status %d: %s", res.StatusCode, string(body))
+	for _, f := range fields {
+		if f == field || f == "*" {
+			return true
+		}
 	}
-	result := new(SearchResponse)
-	if err := json.Unmarshal(body, result); err != nil {
-		return nil, err
+	return false
+}
+
+// populate fills in the fields of the search response derived from the raw
+// Algolia response, converting the hits to stories when asked to
+func populate(result *SearchResponse, convert bool) (*SearchResponse, error) {
+	result.Suggestion = suggestion(result.QueryAfterRemoval)
+	if !convert {
+		return result, nil
 	}
 	// Convert the hits to stories
 	stories, err := toStories(result)
@@ -2,7 +2,13 @@ package hackernews_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/matryer/is"
@@ -101,3 +107,426 @@ func TestFind(t *testing.T) {
 	is.Equal(story.ID, 1)
 	is.Equal(story.Title, "Y Combinator") // title is not Y Combinator
 }
+
+// fakeClient returns a client that sends every request to handler instead of
+// Algolia
+func fakeClient(t testing.TB, handler http.Handler, options ...hackernews.Option) *hackernews.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	options = append(options, hackernews.WithBaseURL(server.URL+"/api/v1"))
+	return hackernews.New(options...)
+}
+
+// serveFile responds to every request with the contents of a testdata file
+func serveFile(t testing.TB, path string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, path)
+	})
+}
+
+func TestDirectReplyCount(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveFile(t, "testdata/item.json"))
+	count, err := hn.DirectReplyCount(ctx, 100)
+	is.NoErr(err)
+	is.Equal(count, 2) // two top-level replies, one removed
+}
+
+func TestSearchResponseFields(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var fields string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("responseFields")
+		fmt.Fprint(w, `{"hits":[{"objectID":"1","title":"Trimmed"}],"nbPages":3}`)
+	}))
+	result, err := hn.Search(ctx, &hackernews.SearchRequest{
+		Query:          "trimmed",
+		ResponseFields: []string{"hits", "nbPages"},
+	})
+	is.NoErr(err)
+	is.Equal(fields, "hits,nbPages")
+	is.Equal(result.NumPages, 3)
+	is.Equal(len(result.Stories), 1)
+	is.Equal(result.Stories[0].Title, "Trimmed")
+	is.Equal(result.NumResults, 0) // not requested
+	is.Equal(result.Query, "")     // not requested
+	is.Equal(result.Page, 0)       // not requested, so not shifted either
+}
+
+func TestSearchRequestRoundTrip(t *testing.T) {
+	is := is.New(t)
+	search := &hackernews.SearchRequest{
+		Query:                "rust & go",
+		Tags:                 "author_pg,(story,poll)",
+		SearchIn:             []string{hackernews.AttributeTitle, hackernews.AttributeStoryText},
+		TypoTolerance:        "min",
+		AdvancedSyntax:       true,
+		OptionalWords:        []string{"rust", "go"},
+		Points:               "points>500,points<1000",
+		CreatedAt:            "created_at_i>1600000000",
+		NumComments:          "num_comments>=10",
+		Page:                 3,
+		ResultsPerPage:       50,
+		ResponseFields:       []string{"hits", "nbPages"},
+		AttributesToRetrieve: []string{"title", "url"},
+		RankingInfo:          true,
+	}
+	parsed, err := hackernews.ParseSearchRequest(search.EncodeQuery())
+	is.NoErr(err)
+	is.Equal(parsed, search)
+	is.Equal(parsed.EncodeQuery(), search.EncodeQuery())
+}
+
+func TestParseSearchRequestSugar(t *testing.T) {
+	is := is.New(t)
+	search := &hackernews.SearchRequest{Points: "> 500", Tags: "story"}
+	parsed, err := hackernews.ParseSearchRequest("?" + search.EncodeQuery())
+	is.NoErr(err)
+	is.Equal(parsed.Tags, "story")
+	is.Equal(parsed.EncodeQuery(), search.EncodeQuery()) // the key is filled in
+}
+
+func TestParseSearchRequestInvalid(t *testing.T) {
+	is := is.New(t)
+	_, err := hackernews.ParseSearchRequest("page=two")
+	is.True(err != nil) // invalid page
+	_, err = hackernews.ParseSearchRequest("numericFilters=karma>10")
+	is.True(err != nil) // unsupported filter
+}
+
+func TestHTMLErrorPage(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "\n<!DOCTYPE html><html><body><h1>502 Bad Gateway</h1>"+strings.Repeat("padding ", 100)+"</body></html>")
+	}))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{Query: "go"})
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "unexpected HTML response with status 200")) // descriptive error
+	is.True(strings.Contains(err.Error(), "502 Bad Gateway"))                          // includes a snippet
+	is.True(!strings.Contains(err.Error(), "</html>"))                                 // snippet is truncated
+	_, err = hn.Find(ctx, 1)
+	is.True(strings.Contains(err.Error(), "unexpected HTML response"))
+}
+
+func TestErrorBodyIsBounded(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, strings.Repeat("x", 1<<20))
+	}))
+	_, err := hn.Find(ctx, 1)
+	var apiErr *hackernews.APIError
+	is.True(errors.As(err, &apiErr))
+	is.Equal(apiErr.StatusCode, http.StatusInternalServerError)
+	is.Equal(len(apiErr.Body), 64<<10) // only the start of the body is kept
+}
+
+func TestNoComments(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var filters, tags string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filters = r.URL.Query().Get("numericFilters")
+		tags = r.URL.Query().Get("tags")
+		fmt.Fprint(w, `{"hits":[
+			{"objectID":"1","num_comments":0},
+			{"objectID":"2"},
+			{"objectID":"3","num_comments":4}
+		]}`)
+	}))
+	result, err := hn.NoComments(ctx, "ask_hn")
+	is.NoErr(err)
+	is.Equal(filters, "num_comments=0")
+	is.Equal(tags, "ask_hn")
+	is.Equal(len(result.Stories), 2)
+	is.Equal(len(result.Hits), 2)
+	for _, story := range result.Stories {
+		is.True(story.NumComments == nil || *story.NumComments == 0) // no comments
+	}
+	_, err = hn.NoComments(ctx, "story,poll")
+	is.True(err != nil) // single tags only
+}
+
+func TestPing(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.URL.Query().Get("hitsPerPage"), "1")
+		fmt.Fprint(w, `{"nbHits":100}`)
+	}))
+	is.NoErr(hn.Ping(ctx))
+}
+
+func TestPingFailing(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"down for maintenance"}`, http.StatusServiceUnavailable)
+	}))
+	err := hn.Ping(ctx)
+	var apiErr *hackernews.APIError
+	is.True(errors.As(err, &apiErr))
+	is.Equal(apiErr.StatusCode, http.StatusServiceUnavailable)
+	is.True(strings.Contains(apiErr.Body, "down for maintenance"))
+}
+
+func TestSearchRawOrder(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"hits":[
+			{"objectID":"3","points":5,"relevancy_score":9000},
+			{"objectID":"1","points":500,"relevancy_score":8000},
+			{"objectID":"2","points":50}
+		]}`)
+	}))
+	result, err := hn.Search(ctx, &hackernews.SearchRequest{Query: "go"})
+	is.NoErr(err)
+	is.Equal(len(result.Stories), 3)
+	is.Equal(result.Stories[0].ID, 3) // Algolia's order is kept
+	is.Equal(result.Stories[1].ID, 1)
+	is.Equal(result.Stories[2].ID, 2)
+	is.Equal(*result.Stories[0].RelevancyScore, 9000) // score carried over
+	is.Equal(*result.Stories[1].RelevancyScore, 8000)
+	is.Equal(result.Stories[2].RelevancyScore, nil) // no score
+}
+
+func TestNumericFilterOperators(t *testing.T) {
+	tests := []struct {
+		search   hackernews.SearchRequest
+		expected string
+	}{
+		{hackernews.SearchRequest{CreatedAt: "= 1609459200"}, "created_at_i=1609459200"},
+		{hackernews.SearchRequest{CreatedAt: "created_at_i = 1609459200"}, "created_at_i=1609459200"},
+		{hackernews.SearchRequest{CreatedAt: "==1609459200"}, "created_at_i=1609459200"},
+		{hackernews.SearchRequest{Points: "> 500"}, "points>500"},
+		{hackernews.SearchRequest{Points: ">= 10, <= 20"}, "points>=10,points<=20"},
+		{hackernews.SearchRequest{NumComments: "!= 0"}, "num_comments!=0"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			is := is.New(t)
+			query, err := url.ParseQuery(test.search.EncodeQuery())
+			is.NoErr(err)
+			is.Equal(query.Get("numericFilters"), test.expected)
+		})
+	}
+}
+
+func TestFindSortsTiesByID(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"type":"story","title":"Ties","children":[
+			{"id":5,"created_at_i":1700000100,"author":"a","text":"Later","children":[]},
+			{"id":4,"created_at_i":1700000000,"author":"b","text":"Same second","children":[
+				{"id":9,"created_at_i":1700000200,"author":"c","text":"Same second","children":[]},
+				{"id":8,"created_at_i":1700000200,"author":"d","text":"Same second","children":[]}
+			]},
+			{"id":3,"created_at_i":1700000000,"author":"c","text":"Same second","children":[]},
+			{"id":2,"created_at_i":1700000000,"author":"d","text":"Same second","children":[]}
+		]}`)
+	}))
+	for i := 0; i < 10; i++ {
+		story, err := hn.Find(ctx, 1)
+		is.NoErr(err)
+		ids := []int{}
+		for _, child := range story.Children {
+			ids = append(ids, child.ID)
+		}
+		is.Equal(ids, []int{2, 3, 4, 5}) // ties are ordered by id
+		is.Equal(story.Children[2].Children[0].ID, 8)
+		is.Equal(story.Children[2].Children[1].ID, 9)
+	}
+}
+
+func TestDefaultsToHTTPS(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var urls []string
+	hn := hackernews.New()
+	hn.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		urls = append(urls, r.URL.Scheme+"://"+r.URL.Host+r.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"hits":[]}`)),
+		}, nil
+	})}
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{Query: "go"})
+	is.NoErr(err)
+	_, err = hn.SearchRecent(ctx, &hackernews.SearchRequest{Query: "go"})
+	is.NoErr(err)
+	is.Equal(urls, []string{
+		"https://hn.algolia.com/api/v1/search",
+		"https://hn.algolia.com/api/v1/search_by_date",
+	})
+}
+
+func TestSearchRecentCanceled(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		fmt.Fprint(w, `{"hits":[]}`)
+	}))
+	_, err := hn.SearchRecent(ctx, &hackernews.SearchRequest{})
+	is.True(errors.Is(err, context.Canceled))
+	_, err = hn.Newest(ctx)
+	is.True(errors.Is(err, context.Canceled))
+	_, err = hn.AskHN(ctx)
+	is.True(errors.Is(err, context.Canceled))
+	_, err = hn.ShowHN(ctx)
+	is.True(errors.Is(err, context.Canceled))
+	is.True(!called) // no requests were sent
+}
+
+func TestFindNotFound(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"Not Found"}`)
+	}))
+	_, err := hn.Find(ctx, 999)
+	is.True(errors.Is(err, hackernews.ErrNotFound))
+	is.True(!errors.Is(err, hackernews.ErrRateLimited))
+	var apiErr *hackernews.APIError
+	is.True(errors.As(err, &apiErr))
+	is.Equal(apiErr.Body, `{"error":"Not Found"}`) // original body is kept
+}
+
+func TestFindMostPoints(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"type":"story","title":"Best","children":[
+			{"id":2,"created_at_i":1700000000,"points":3,"author":"a","text":"Old","children":[
+				{"id":6,"created_at_i":1700000300,"points":1,"author":"b","text":"Meh","children":[]},
+				{"id":7,"created_at_i":1700000400,"points":9,"author":"c","text":"Great","children":[]}
+			]},
+			{"id":3,"created_at_i":1700000100,"points":10,"author":"b","text":"Best","children":[]},
+			{"id":4,"created_at_i":1700000200,"author":"c","text":"No points","children":[]},
+			{"id":5,"created_at_i":1700000050,"points":3,"author":"d","text":"Tie","children":[]}
+		]}`)
+	}), hackernews.WithCommentOrder(hackernews.MostPoints))
+	story, err := hn.Find(ctx, 1)
+	is.NoErr(err)
+	ids := []int{}
+	for _, child := range story.Children {
+		ids = append(ids, child.ID)
+	}
+	is.Equal(ids, []int{3, 2, 5, 4}) // ties go to the older comment
+	is.Equal(story.Children[1].Children[0].ID, 7)
+	is.Equal(story.Children[1].Children[1].ID, 6)
+}
+
+func TestSearchText(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"hits":[
+			{"objectID":"1","title":"Ask HN: Why?","story_text":"Just wondering"},
+			{"objectID":"2","comment_text":"Because"},
+			{"objectID":"3","title":"A link","url":"https://example.com"}
+		]}`)
+	}))
+	for _, search := range []func(context.Context, *hackernews.SearchRequest) (*hackernews.SearchResponse, error){hn.Search, hn.SearchRecent} {
+		result, err := search(ctx, &hackernews.SearchRequest{})
+		is.NoErr(err)
+		is.Equal(len(result.Stories), 3)
+		is.Equal(*result.Stories[0].Text, "Just wondering")
+		is.Equal(*result.Stories[1].Text, "Because")
+		is.Equal(result.Stories[2].Text, nil)
+	}
+}
+
+func TestSearchCommentContext(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"hits":[{
+			"objectID":"2","comment_text":"Nice","story_id":1,"parent_id":1,"num_comments":0,
+			"story_title":"Show HN: A thing","story_url":"https://example.com",
+			"relevancy_score":42,"_tags":["comment","author_bob","story_1"]
+		}]}`)
+	}))
+	result, err := hn.Search(ctx, &hackernews.SearchRequest{Tags: "comment"})
+	is.NoErr(err)
+	comment := result.Stories[0]
+	is.Equal(*comment.StoryID, 1)
+	is.Equal(*comment.ParentID, 1)
+	is.Equal(*comment.NumComments, 0)
+	is.Equal(*comment.RelevancyScore, 42)
+	is.Equal(comment.Tags, []string{"comment", "author_bob", "story_1"})
+	is.Equal(*comment.StoryTitle, "Show HN: A thing")
+	is.Equal(*comment.StoryURL, "https://example.com")
+}
+
+func TestBest(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var query url.Values
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"hits":[
+			{"objectID":"1","points":10},
+			{"objectID":"2","points":300},
+			{"objectID":"3","points":50},
+			{"objectID":"4","points":300}
+		]}`)
+	}))
+	stories, err := hn.Best(ctx)
+	is.NoErr(err)
+	is.Equal(query.Get("tags"), "story")
+	is.Equal(query.Get("hitsPerPage"), "34")
+	is.True(strings.HasPrefix(query.Get("numericFilters"), "created_at_i>")) // recent stories only
+	ids := []int{}
+	for _, story := range stories {
+		ids = append(ids, story.ID)
+	}
+	is.Equal(ids, []int{2, 4, 3, 1}) // most points first
+}
+
+func TestSearchRequestURL(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, "http://"+r.Host+r.URL.String())
+		fmt.Fprint(w, `{"hits":[]}`)
+	}))
+	defer server.Close()
+	hn := hackernews.New(hackernews.WithBaseURL(server.URL))
+	search := &hackernews.SearchRequest{Query: "rust & go", Tags: "story", Page: 2, Points: "> 100"}
+	result, err := hn.Search(ctx, search)
+	is.NoErr(err)
+	recent, err := hn.SearchRecent(ctx, search)
+	is.NoErr(err)
+	is.Equal(result.RequestURL, requested[0])
+	is.Equal(recent.RequestURL, requested[1])
+	is.True(strings.HasPrefix(result.RequestURL, server.URL+"/search?"))
+	is.True(strings.Contains(result.RequestURL, "query=rust+%26+go"))
+	is.True(strings.Contains(result.RequestURL, "page=1")) // Algolia's page
+}
+
+func TestSearchIn(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var restrict string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		restrict = r.URL.Query().Get("restrictSearchableAttributes")
+		fmt.Fprint(w, `{"hits":[]}`)
+	}))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{Query: "go", SearchIn: []string{hackernews.AttributeTitle}})
+	is.NoErr(err)
+	is.Equal(restrict, "title")
+	_, err = hn.Search(ctx, &hackernews.SearchRequest{Query: "go", SearchIn: []string{"text"}})
+	is.True(err != nil) // unknown attribute
+}
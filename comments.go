@@ -0,0 +1,169 @@
+package hackernews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// CommentsBFS returns every comment in the tree in breadth-first order, so all
+// the top-level comments come first, then their replies and so on.
+func (s *Story) CommentsBFS() []Children {
+	comments := []Children{}
+	queue := s.Children
+	for len(queue) > 0 {
+		var next []Children
+		for _, comment := range queue {
+			comments = append(comments, comment)
+			next = append(next, comment.Children...)
+		}
+		queue = next
+	}
+	return comments
+}
+
+// RepliesTo returns the comments that replied directly to a comment written by
+// author, in tree order. Replies to the story itself aren't included.
+func (s *Story) RepliesTo(author string) []Children {
+	replies := []Children{}
+	var walk func(children []Children)
+	walk = func(children []Children) {
+		for _, child := range children {
+			if child.Author != nil && *child.Author == author {
+				replies = append(replies, child.Children...)
+			}
+			walk(child.Children)
+		}
+	}
+	walk(s.Children)
+	return replies
+}
+
+// ReverseComments reverses the order of the comments at every level of the
+// tree. After Find, this turns oldest-first into newest-first.
+func (s *Story) ReverseComments() {
+	reverseChildren(s.Children)
+}
+
+func reverseChildren(children []Children) {
+	for i, j := 0, len(children)-1; i < j; i, j = i+1, j-1 {
+		children[i], children[j] = children[j], children[i]
+	}
+	for _, child := range children {
+		reverseChildren(child.Children)
+	}
+}
+
+// TopComment returns the top-level comment that sparked the most discussion,
+// measured by the number of replies beneath it. Ties go to the earlier comment
+// in the tree. It returns nil when the story has no comments.
+func (s *Story) TopComment() *Children {
+	var top *Children
+	most := -1
+	for i := range s.Children {
+		if replies := countReplies(s.Children[i].Children); replies > most {
+			top, most = &s.Children[i], replies
+		}
+	}
+	return top
+}
+
+// countReplies counts every comment in the tree
+func countReplies(children []Children) int {
+	count := len(children)
+	for _, child := range children {
+		count += countReplies(child.Children)
+	}
+	return count
+}
+
+// CommentCount counts every comment in the story's tree, at every depth. Unlike
+// NumComments, it only counts the comments that were actually received, so
+// removed comments aren't included, even when kept with WithDeletedComments.
+func (s *Story) CommentCount() int {
+	count := 0
+	s.Walk(func(depth int, c *Children) error {
+		if !c.Deleted {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
+// Subtree finds the comment with the given id anywhere in the tree and returns
+// it along with its replies. It returns false when there's no such comment.
+func (s *Story) Subtree(commentID int) (*Children, bool) {
+	return findChild(s.Children, commentID)
+}
+
+func findChild(children []Children, id int) (*Children, bool) {
+	for i := range children {
+		if children[i].ID == id {
+			return &children[i], true
+		}
+		if child, ok := findChild(children[i].Children, id); ok {
+			return child, true
+		}
+	}
+	return nil, false
+}
+
+// SkipChildren can be returned from the function passed to Walk to skip the
+// replies to the current comment.
+//
+//lint:ignore ST1012 it's a signal rather than a failure, named like fs.SkipDir
+var SkipChildren = errors.New("hackernews: skip children")
+
+// Walk visits every comment in the tree depth-first, calling fn with each
+// comment before its replies. Top-level comments have a depth of 1. Returning
+// SkipChildren from fn skips the comment's replies. Any other error stops the
+// walk and is returned.
+func (s *Story) Walk(fn func(depth int, c *Children) error) error {
+	return walkChildren(s.Children, 1, fn)
+}
+
+func walkChildren(children []Children, depth int, fn func(depth int, c *Children) error) error {
+	for i := range children {
+		if err := fn(depth, &children[i]); err != nil {
+			if err == SkipChildren {
+				continue
+			}
+			return err
+		}
+		if err := walkChildren(children[i].Children, depth+1, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RootStory finds the story that the comment belongs to, the same way as Find.
+// It follows the comment's StoryID when it's set, and otherwise walks up the
+// comment's parents until it reaches the story.
+func (c *Client) RootStory(ctx context.Context, comment *Children) (*Story, error) {
+	if comment.StoryID != 0 {
+		return c.Find(ctx, comment.StoryID)
+	}
+	seen := map[int]bool{comment.ID: true}
+	parent := comment.ParentID
+	for parent != 0 {
+		if seen[parent] {
+			return nil, fmt.Errorf("hackernews: comment %d has a cycle in its parents", comment.ID)
+		}
+		seen[parent] = true
+		item, err := c.fetchItem(ctx, parent)
+		if err != nil {
+			return nil, err
+		}
+		if item.StoryID != nil && *item.StoryID != 0 && *item.StoryID != item.ID {
+			return c.Find(ctx, *item.StoryID)
+		}
+		if item.ParentID == nil || *item.ParentID == 0 {
+			item.Children = c.processChildren(item.Children)
+			return item, nil
+		}
+		parent = *item.ParentID
+	}
+	return nil, fmt.Errorf("hackernews: comment %d has no story", comment.ID)
+}
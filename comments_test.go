@@ -0,0 +1,316 @@
+package hackernews_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+// findFixture loads the story in testdata/item.json through Find
+func findFixture(t testing.TB) *hackernews.Story {
+	t.Helper()
+	hn := fakeClient(t, serveFile(t, "testdata/item.json"))
+	story, err := hn.Find(context.Background(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return story
+}
+
+// commentIDs returns the ids of the comments in order
+func commentIDs(comments []hackernews.Children) (ids []int) {
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	return ids
+}
+
+func TestCommentsBFS(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	comments := story.CommentsBFS()
+	is.Equal(commentIDs(comments), []int{102, 101, 106, 104, 103, 105})
+}
+
+func TestCommentsBFSEmpty(t *testing.T) {
+	is := is.New(t)
+	story := &hackernews.Story{ID: 1}
+	is.Equal(len(story.CommentsBFS()), 0)
+}
+
+func TestRepliesTo(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	is.Equal(commentIDs(story.RepliesTo("bob")), []int{104, 103})
+	is.Equal(commentIDs(story.RepliesTo("alice")), []int{105}) // replies to the story don't count
+	is.Equal(len(story.RepliesTo("nobody")), 0)
+}
+
+func TestRepliesToNilAuthor(t *testing.T) {
+	is := is.New(t)
+	bob := "bob"
+	story := &hackernews.Story{
+		Children: []hackernews.Children{
+			{ID: 1, Children: []hackernews.Children{
+				{ID: 2, Author: &bob},
+			}},
+			{ID: 3, Author: &bob, Children: []hackernews.Children{
+				{ID: 4},
+			}},
+		},
+	}
+	is.Equal(commentIDs(story.RepliesTo("bob")), []int{4})
+	is.Equal(len(story.RepliesTo("")), 0) // removed authors don't match
+}
+
+func TestReverseComments(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	is.Equal(commentIDs(story.Children), []int{102, 101})
+	is.Equal(commentIDs(story.Children[1].Children), []int{104, 103})
+	story.ReverseComments()
+	is.Equal(commentIDs(story.Children), []int{101, 102})             // top level
+	is.Equal(commentIDs(story.Children[0].Children), []int{103, 104}) // second level
+	story.ReverseComments()
+	is.Equal(commentIDs(story.Children), []int{102, 101}) // flips back
+}
+
+func TestTopComment(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	top := story.TopComment()
+	is.True(top != nil)
+	is.Equal(top.ID, 101) // three replies beneath it
+	is.Equal(*top.Author, "bob")
+	is.Equal((&hackernews.Story{}).TopComment(), nil) // no comments
+}
+
+func TestSubtree(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	subtree, ok := story.Subtree(103)
+	is.True(ok)
+	is.Equal(subtree.ID, 103)
+	is.Equal(*subtree.Author, "alice")
+	is.Equal(commentIDs(subtree.Children), []int{105}) // descendants come along
+	subtree, ok = story.Subtree(105)
+	is.True(ok)
+	is.Equal(len(subtree.Children), 0)
+	_, ok = story.Subtree(999)
+	is.True(!ok) // not found
+}
+
+func TestWalk(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	var ids, depths []int
+	err := story.Walk(func(depth int, c *hackernews.Children) error {
+		ids = append(ids, c.ID)
+		depths = append(depths, depth)
+		return nil
+	})
+	is.NoErr(err)
+	is.Equal(ids, []int{102, 106, 101, 104, 103, 105})
+	is.Equal(depths, []int{1, 2, 1, 2, 2, 3})
+}
+
+func TestWalkSkipChildren(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	var ids []int
+	err := story.Walk(func(depth int, c *hackernews.Children) error {
+		ids = append(ids, c.ID)
+		if c.ID == 101 {
+			return hackernews.SkipChildren
+		}
+		return nil
+	})
+	is.NoErr(err)
+	is.Equal(ids, []int{102, 106, 101})
+}
+
+func TestWalkError(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	stop := errors.New("stop")
+	var ids []int
+	err := story.Walk(func(depth int, c *hackernews.Children) error {
+		ids = append(ids, c.ID)
+		if c.ID == 106 {
+			return stop
+		}
+		return nil
+	})
+	is.Equal(err, stop)
+	is.Equal(ids, []int{102, 106})
+}
+
+func TestWithDeletedComments(t *testing.T) {
+	is := is.New(t)
+	hn := fakeClient(t, serveFile(t, "testdata/item.json"), hackernews.WithDeletedComments(true))
+	story, err := hn.Find(context.Background(), 100)
+	is.NoErr(err)
+	is.Equal(commentIDs(story.Children), []int{102, 101, 107})
+	deleted := story.Children[2]
+	is.True(deleted.Deleted)
+	is.Equal(deleted.Author, nil)
+	is.Equal(commentIDs(deleted.Children), []int{108}) // replies are kept
+	is.True(!deleted.Children[0].Deleted)
+	is.True(!story.Children[0].Deleted)
+}
+
+func TestWithoutDeletedComments(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	is.Equal(commentIDs(story.Children), []int{102, 101})
+}
+
+// deepThread is an item whose comments nest depth levels deep. Every level has
+// a deleted comment and an older reply that should sort before the thread.
+func deepThread(depth int) string {
+	var thread strings.Builder
+	thread.WriteString(`{"id":1,"type":"story","title":"Deep","children":`)
+	for level := 1; level <= depth; level++ {
+		fmt.Fprintf(&thread, `[{"id":%d,"author":"a","text":"t","created_at_i":2,"children":`, level*10)
+	}
+	thread.WriteString(`[]`)
+	for level := depth; level >= 1; level-- {
+		id := level * 10
+		fmt.Fprintf(&thread, `},{"id":%d,"author":null,"text":null,"created_at_i":0,"children":[]}`, id+1)
+		fmt.Fprintf(&thread, `,{"id":%d,"author":"b","text":"t","created_at_i":1,"children":[]}]`, id+2)
+	}
+	thread.WriteString(`}`)
+	return thread.String()
+}
+
+func TestDeepThread(t *testing.T) {
+	is := is.New(t)
+	const depth = 500
+	requests := 0
+	hn := fakeClient(t, serveTree(map[string]string{"1": deepThread(depth)}, &requests))
+	story, err := hn.Find(context.Background(), 1)
+	is.NoErr(err)
+	children := story.Children
+	for level := 1; level <= depth; level++ {
+		is.Equal(commentIDs(children), []int{level*10 + 2, level * 10}) // filtered and sorted
+		children = children[1].Children
+	}
+	is.Equal(len(children), 0)
+}
+
+func TestDeepThreadStack(t *testing.T) {
+	is := is.New(t)
+	const depth = 100000
+	author, text, older := "a", "<p>deep", "b"
+	var thread []hackernews.Children
+	for level := depth; level >= 1; level-- {
+		id := level * 10
+		thread = []hackernews.Children{
+			{ID: id, Author: &author, Text: &text, CreatedAtI: 2, Children: thread},
+			{ID: id + 1, CreatedAtI: 0}, // deleted
+			{ID: id + 2, Author: &older, Text: &text, CreatedAtI: 1},
+		}
+	}
+	// Walking 100,000 levels recursively needs far more than 1MB of stack, and
+	// going over the limit crashes the test binary
+	defer debug.SetMaxStack(debug.SetMaxStack(1 << 20))
+	hn := hackernews.New(hackernews.WithDecodeChildrenText(true))
+	children := hn.ProcessChildren(thread)
+	for level := 1; level <= depth; level++ {
+		if len(children) != 2 || children[0].ID != level*10+2 || children[1].ID != level*10 {
+			t.Fatalf("level %d wasn't filtered and sorted: %v", level, commentIDs(children))
+		}
+		is.Equal(*children[1].Text, "deep")
+		children = children[1].Children
+	}
+	is.Equal(len(children), 0)
+}
+
+func TestCommentCount(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	is.Equal(story.CommentCount(), 6) // three levels deep, deleted comment dropped
+	is.Equal((&hackernews.Story{}).CommentCount(), 0)
+	hn := fakeClient(t, serveFile(t, "testdata/item.json"), hackernews.WithDeletedComments(true))
+	story, err := hn.Find(context.Background(), 100)
+	is.NoErr(err)
+	is.Equal(story.CommentCount(), 7) // the reply to the deleted comment counts
+}
+
+func TestComments(t *testing.T) {
+	is := is.New(t)
+	hn := fakeClient(t, serveFile(t, "testdata/item.json"))
+	comments, err := hn.Comments(context.Background(), 100)
+	is.NoErr(err)
+	is.Equal(commentIDs(comments), commentIDs(findFixture(t).Children))
+	is.Equal(commentIDs(comments), []int{102, 101})
+	is.Equal(commentIDs(comments[1].Children), []int{104, 103})
+}
+
+// serveTree serves items from a map of id to JSON, counting the requests
+func serveTree(items map[string]string, requests *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		item, ok := items[path.Base(r.URL.Path)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, item)
+	})
+}
+
+func TestRootStory(t *testing.T) {
+	is := is.New(t)
+	requests := 0
+	hn := fakeClient(t, serveTree(map[string]string{
+		"1": `{"id":1,"type":"story","title":"Root","parent_id":null,"story_id":1,"children":[]}`,
+	}, &requests))
+	story, err := hn.RootStory(context.Background(), &hackernews.Children{ID: 3, ParentID: 2, StoryID: 1})
+	is.NoErr(err)
+	is.Equal(story.Title, "Root")
+	is.Equal(requests, 1) // follows the story id directly
+}
+
+func TestRootStoryParents(t *testing.T) {
+	is := is.New(t)
+	requests := 0
+	hn := fakeClient(t, serveTree(map[string]string{
+		"1": `{"id":1,"type":"story","title":"Root","parent_id":null,"children":[]}`,
+		"2": `{"id":2,"type":"comment","parent_id":1,"children":[]}`,
+	}, &requests))
+	story, err := hn.RootStory(context.Background(), &hackernews.Children{ID: 3, ParentID: 2})
+	is.NoErr(err)
+	is.Equal(story.ID, 1)
+	is.Equal(story.Title, "Root")
+	is.Equal(requests, 2)
+}
+
+func TestRootStoryCycle(t *testing.T) {
+	is := is.New(t)
+	requests := 0
+	hn := fakeClient(t, serveTree(map[string]string{
+		"2": `{"id":2,"type":"comment","parent_id":4,"children":[]}`,
+		"4": `{"id":4,"type":"comment","parent_id":2,"children":[]}`,
+	}, &requests))
+	_, err := hn.RootStory(context.Background(), &hackernews.Children{ID: 3, ParentID: 2})
+	is.True(err != nil)
+	is.Equal(requests, 2)
+}
+
+func TestRootStoryMissing(t *testing.T) {
+	is := is.New(t)
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("no requests should be made")
+	}))
+	_, err := hn.RootStory(context.Background(), &hackernews.Children{ID: 3})
+	is.True(err != nil)
+}
@@ -0,0 +1,91 @@
+package hackernews
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Crawler repeatedly fetches the newest stories, only returning the ones it
+// hasn't seen before. Its position can be saved with State and picked up again
+// later with Restore.
+type Crawler struct {
+	client *Client
+	search SearchRequest
+	state  crawlerState
+}
+
+// crawlerState is the position of the crawler
+type crawlerState struct {
+	LastID        int `json:"lastID"`
+	LastCreatedAt int `json:"lastCreatedAt"`
+}
+
+// NewCrawler creates a crawler that crawls the results of the search, newest
+// first. A nil search crawls every story.
+func NewCrawler(client *Client, search *SearchRequest) *Crawler {
+	crawler := &Crawler{client: client}
+	if search != nil {
+		crawler.search = *search
+	}
+	if crawler.search.Tags == "" {
+		crawler.search.Tags = "story"
+	}
+	return crawler
+}
+
+// Next returns the stories that were created since the last call, oldest
+// first. The first call only returns the most recent page of stories.
+func (c *Crawler) Next(ctx context.Context) ([]*Story, error) {
+	search := c.search
+	if c.state.LastCreatedAt > 0 {
+		// Stories created in the same second as the last one may be new too.
+		// The cursor is ANDed with the caller's filters rather than replacing
+		// them, and copied so the caller's slice isn't appended to.
+		filters := append([]NumericFilter{}, search.Filters...)
+		search.Filters = append(filters, numericFilter("created_at_i", ">=", int64(c.state.LastCreatedAt)))
+	}
+	stories := []*Story{}
+	for page := 1; ; page++ {
+		search.Page = page
+		result, err := c.client.searchRecentStories(ctx, &search)
+		if err != nil {
+			return nil, err
+		}
+		for _, story := range result.Stories {
+			if story.ID > c.state.LastID {
+				stories = append(stories, story)
+			}
+		}
+		if c.state.LastID == 0 || len(result.Hits) == 0 || !result.HasNextPage() {
+			break
+		}
+	}
+	sort.Slice(stories, func(i, j int) bool {
+		return stories[i].ID < stories[j].ID
+	})
+	for _, story := range stories {
+		c.state.LastID = story.ID
+		if story.CreatedAtI > c.state.LastCreatedAt {
+			c.state.LastCreatedAt = story.CreatedAtI
+		}
+	}
+	return stories, nil
+}
+
+// State returns the crawler's position so it can be persisted
+func (c *Crawler) State() []byte {
+	state, _ := json.Marshal(c.state)
+	return state
+}
+
+// Restore the crawler's position from a previous call to State
+func (c *Crawler) Restore(state []byte) error {
+	var restored crawlerState
+	if err := json.Unmarshal(state, &restored); err != nil {
+		return fmt.Errorf("hackernews: unable to restore crawler: %w", err)
+	}
+	c.state = restored
+	return nil
+}
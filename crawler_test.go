@@ -0,0 +1,124 @@
+package hackernews_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+// feed is a fake search_by_date endpoint that new stories can be posted to
+type feed struct {
+	mu      sync.Mutex
+	stories []map[string]interface{}
+	filters []string
+}
+
+func (f *feed) post(id int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stories = append([]map[string]interface{}{{
+		"objectID":     strconv.Itoa(id),
+		"title":        "Story " + strconv.Itoa(id),
+		"created_at_i": 1700000000 + id*60,
+	}}, f.stories...)
+}
+
+func (f *feed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.filters = append(f.filters, r.URL.Query().Get("numericFilters"))
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"hits":    f.stories,
+		"page":    0,
+		"nbPages": 1,
+	})
+}
+
+// storyIDs returns the ids of the stories in order
+func storyIDs(stories []*hackernews.Story) (ids []int) {
+	for _, story := range stories {
+		ids = append(ids, story.ID)
+	}
+	return ids
+}
+
+func TestCrawler(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	feed := new(feed)
+	feed.post(1)
+	feed.post(2)
+	feed.post(3)
+	crawler := hackernews.NewCrawler(fakeClient(t, feed), nil)
+	stories, err := crawler.Next(ctx)
+	is.NoErr(err)
+	is.Equal(storyIDs(stories), []int{1, 2, 3}) // oldest first
+	feed.post(4)
+	feed.post(5)
+	stories, err = crawler.Next(ctx)
+	is.NoErr(err)
+	is.Equal(storyIDs(stories), []int{4, 5}) // only the new stories
+	stories, err = crawler.Next(ctx)
+	is.NoErr(err)
+	is.Equal(len(stories), 0) // nothing new
+	is.Equal(feed.filters, []string{"", "created_at_i>=1700000180", "created_at_i>=1700000300"})
+}
+
+func TestCrawlerRestore(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	feed := new(feed)
+	feed.post(1)
+	feed.post(2)
+	hn := fakeClient(t, feed)
+	crawler := hackernews.NewCrawler(hn, &hackernews.SearchRequest{Tags: "show_hn"})
+	_, err := crawler.Next(ctx)
+	is.NoErr(err)
+	state := crawler.State()
+	feed.post(3)
+	restored := hackernews.NewCrawler(hn, &hackernews.SearchRequest{Tags: "show_hn"})
+	is.NoErr(restored.Restore(state))
+	stories, err := restored.Next(ctx)
+	is.NoErr(err)
+	is.Equal(storyIDs(stories), []int{3}) // picks up where it left off
+	is.True(restored.Restore([]byte("not json")) != nil)
+}
+
+func TestCrawlerWithoutPopulateStories(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	feed := new(feed)
+	feed.post(1)
+	feed.post(2)
+	crawler := hackernews.NewCrawler(fakeClient(t, feed, hackernews.WithPopulateStories(false)), nil)
+	stories, err := crawler.Next(ctx)
+	is.NoErr(err)
+	is.Equal(storyIDs(stories), []int{1, 2}) // the crawler always needs stories
+}
+
+func TestCrawlerKeepsCreatedAt(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	feed := new(feed)
+	feed.post(1)
+	crawler := hackernews.NewCrawler(fakeClient(t, feed), &hackernews.SearchRequest{
+		CreatedAt: ">1700000000",
+		Filters:   []hackernews.NumericFilter{hackernews.PointsAtLeast(1)},
+	})
+	_, err := crawler.Next(ctx)
+	is.NoErr(err)
+	feed.post(2)
+	stories, err := crawler.Next(ctx)
+	is.NoErr(err)
+	is.Equal(storyIDs(stories), []int{2})
+	is.Equal(feed.filters, []string{
+		"created_at_i>1700000000,points>=1",
+		"created_at_i>1700000000,points>=1,created_at_i>=1700000060", // the caller's window is kept
+	})
+}
@@ -0,0 +1,77 @@
+package hackernews
+
+import (
+	"context"
+	"sync"
+)
+
+// Dashboard combines the main Hacker News listings
+type Dashboard struct {
+	FrontPage []*Story
+	Newest    []*Story
+	AskHN     []*Story
+	ShowHN    []*Story
+	Jobs      []*Story
+
+	// Errors holds the error for each section that failed to load, keyed by
+	// the section's field name (e.g. "AskHN").
+	Errors map[string]error
+}
+
+// Dashboard fetches the front page, newest, Ask HN, Show HN and jobs listings
+// concurrently. A section that fails to load is left empty and its error is
+// recorded in Dashboard.Errors. An error is only returned when every section
+// failed.
+func (c *Client) Dashboard(ctx context.Context) (*Dashboard, error) {
+	dashboard := &Dashboard{Errors: map[string]error{}}
+	sections := []struct {
+		name    string
+		stories *[]*Story
+		fetch   func(context.Context) ([]*Story, error)
+	}{
+		{"FrontPage", &dashboard.FrontPage, c.FrontPage},
+		{"Newest", &dashboard.Newest, c.Newest},
+		{"AskHN", &dashboard.AskHN, c.AskHN},
+		{"ShowHN", &dashboard.ShowHN, c.ShowHN},
+		{"Jobs", &dashboard.Jobs, c.jobs},
+	}
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	for _, section := range sections {
+		wg.Add(1)
+		go func(name string, stories *[]*Story, fetch func(context.Context) ([]*Story, error)) {
+			defer wg.Done()
+			result, err := fetch(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				dashboard.Errors[name] = err
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			*stories = result
+		}(section.name, section.stories, section.fetch)
+	}
+	wg.Wait()
+	if len(dashboard.Errors) == len(sections) {
+		return nil, firstErr
+	}
+	return dashboard, nil
+}
+
+// jobs gets the results on https://news.ycombinator.com/jobs
+func (c *Client) jobs(ctx context.Context) ([]*Story, error) {
+	result, err := c.searchRecentStories(ctx, &SearchRequest{
+		Tags:           "job",
+		ResultsPerPage: c.perPage(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Stories, nil
+}
@@ -0,0 +1,60 @@
+package hackernews_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// tagHandler responds with a single story per tag, failing the tags in failing
+func tagHandler(failing ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tags := r.URL.Query().Get("tags")
+		for _, tag := range failing {
+			if tag == tags {
+				http.Error(w, `{"message":"oops"}`, http.StatusInternalServerError)
+				return
+			}
+		}
+		fmt.Fprintf(w, `{"hits":[{"objectID":"%d","title":%q}]}`, len(tags), tags)
+	})
+}
+
+func TestDashboard(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, tagHandler())
+	dashboard, err := hn.Dashboard(ctx)
+	is.NoErr(err)
+	is.Equal(len(dashboard.Errors), 0)
+	is.Equal(dashboard.FrontPage[0].Title, "front_page")
+	is.Equal(dashboard.Newest[0].Title, "story")
+	is.Equal(dashboard.AskHN[0].Title, "ask_hn")
+	is.Equal(dashboard.ShowHN[0].Title, "show_hn")
+	is.Equal(dashboard.Jobs[0].Title, "job")
+}
+
+func TestDashboardPartial(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, tagHandler("ask_hn", "job"))
+	dashboard, err := hn.Dashboard(ctx)
+	is.NoErr(err) // one failing feed doesn't fail the dashboard
+	is.Equal(len(dashboard.Errors), 2)
+	is.True(dashboard.Errors["AskHN"] != nil)
+	is.True(dashboard.Errors["Jobs"] != nil)
+	is.Equal(len(dashboard.AskHN), 0)
+	is.Equal(len(dashboard.FrontPage), 1)
+	is.Equal(len(dashboard.ShowHN), 1)
+}
+
+func TestDashboardFailing(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, tagHandler("front_page", "story", "ask_hn", "show_hn", "job"))
+	_, err := hn.Dashboard(ctx)
+	is.True(err != nil) // every section failed
+}
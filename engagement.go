@@ -0,0 +1,71 @@
+package hackernews
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// LeastEngaged returns the stories created within the window (e.g. the last 24
+// hours) that received the least engagement, sorted by points and then number
+// of comments, lowest first. When the soft budget runs out, the least engaged
+// of the stories so far are returned with ErrBudgetExceeded.
+//
+// Stories are fetched newest first. Algolia stops paging after 1000 hits, so
+// once a search runs out, the next one picks up from the oldest story so far
+// until the window is covered.
+func (c *Client) LeastEngaged(ctx context.Context, window time.Duration, limit int) ([]*Story, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	since := time.Now().Add(-window)
+	stories := []*Story{}
+	seen := map[int]bool{}
+	var before time.Time
+	var err error
+	for {
+		var found []*Story
+		found, err = c.uniqueStories(ctx, c.searchRecentStories, &SearchRequest{
+			Tags:           "story",
+			CreatedAt:      ">" + strconv.FormatInt(since.Unix(), 10),
+			CreatedBefore:  before,
+			ResultsPerPage: maxResultsPerPage,
+		}, seen)
+		for _, story := range found {
+			if story.createdAt().After(since) {
+				stories = append(stories, story)
+			}
+			if created := story.createdAt(); !created.IsZero() && (before.IsZero() || created.Before(before)) {
+				before = created
+			}
+		}
+		if err != nil || len(found) == 0 {
+			break
+		}
+		// Include the oldest second again, since more stories may share it
+		before = before.Add(time.Second)
+	}
+	if err != nil && !errors.Is(err, ErrBudgetExceeded) {
+		return nil, err
+	}
+	sort.SliceStable(stories, func(i, j int) bool {
+		if stories[i].Points != stories[j].Points {
+			return stories[i].Points < stories[j].Points
+		}
+		return numComments(stories[i]) < numComments(stories[j])
+	})
+	if len(stories) > limit {
+		stories = stories[:limit]
+	}
+	return stories, err
+}
+
+// numComments treats a missing comment count as zero
+func numComments(story *Story) int {
+	if story.NumComments == nil {
+		return 0
+	}
+	return *story.NumComments
+}
@@ -0,0 +1,102 @@
+package hackernews_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestLeastEngaged(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	now := time.Now()
+	ago := func(d time.Duration) int64 {
+		return now.Add(-d).Unix()
+	}
+	var filters, path string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filters = r.URL.Query().Get("numericFilters")
+		path = r.URL.Path
+		fmt.Fprintf(w, `{"nbPages":1,"hits":[
+			{"objectID":"1","points":50,"num_comments":10,"created_at_i":%d},
+			{"objectID":"2","points":1,"num_comments":3,"created_at_i":%d},
+			{"objectID":"3","points":1,"created_at_i":%d},
+			{"objectID":"4","points":0,"created_at_i":%d},
+			{"objectID":"5","points":2,"num_comments":0,"created_at_i":%d}
+		]}`, ago(time.Hour), ago(2*time.Hour), ago(3*time.Hour), ago(48*time.Hour), ago(5*time.Hour))
+	}))
+	stories, err := hn.LeastEngaged(ctx, 24*time.Hour, 3)
+	is.NoErr(err)
+	is.True(strings.HasPrefix(filters, "created_at_i>"))
+	is.Equal(path, "/api/v1/search_by_date")    // newest first, not by points
+	is.Equal(storyIDs(stories), []int{3, 2, 5}) // 4 is outside the window
+	for i := 1; i < len(stories); i++ {
+		is.True(stories[i-1].Points <= stories[i].Points) // ascending points
+	}
+}
+
+// limitedHandler serves the stories newest first, two per page, and like
+// Algolia stops paging after a handful of hits
+func limitedHandler(t testing.TB, stories []map[string]interface{}) http.Handler {
+	const perPage, maxHits = 2, 4
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		before := int64(math.MaxInt64)
+		for _, filter := range strings.Split(query.Get("numericFilters"), ",") {
+			if strings.HasPrefix(filter, "created_at_i<") {
+				before, _ = strconv.ParseInt(strings.TrimPrefix(filter, "created_at_i<"), 10, 64)
+			}
+		}
+		matches := []map[string]interface{}{}
+		for _, story := range stories {
+			if story["created_at_i"].(int64) < before {
+				matches = append(matches, story)
+			}
+		}
+		if len(matches) > maxHits {
+			matches = matches[:maxHits]
+		}
+		page, _ := strconv.Atoi(query.Get("page"))
+		hits := []map[string]interface{}{}
+		if start := page * perPage; start < len(matches) {
+			end := start + perPage
+			if end > len(matches) {
+				end = len(matches)
+			}
+			hits = matches[start:end]
+		}
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"hits":    hits,
+			"page":    page,
+			"nbPages": (len(matches) + perPage - 1) / perPage,
+		}); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestLeastEngagedPastPagingLimit(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	now := time.Now()
+	stories := []map[string]interface{}{}
+	for i := 1; i <= 9; i++ {
+		stories = append(stories, map[string]interface{}{
+			"objectID":     strconv.Itoa(i),
+			"points":       100 - i, // the oldest stories have the fewest points
+			"created_at_i": now.Add(-time.Duration(i) * time.Hour).Unix(),
+		})
+	}
+	hn := fakeClient(t, limitedHandler(t, stories))
+	least, err := hn.LeastEngaged(ctx, 24*time.Hour, 2)
+	is.NoErr(err)
+	is.Equal(storyIDs(least), []int{9, 8}) // found past the first 4 hits
+}
@@ -0,0 +1,127 @@
+package hackernews
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoResults is returned by helpers that look for something specific (e.g.
+// Similar) when nothing matches. Methods that list stories, like Search or
+// FrontPage, return an empty slice instead.
+var ErrNoResults = errors.New("hackernews: no results")
+
+// ErrBudgetExceeded is returned by methods that make many requests (e.g.
+// SearchN, FindMany) when they stop early because the soft budget set with
+// WithSoftBudget ran out. The results gathered so far are returned with it.
+var ErrBudgetExceeded = errors.New("hackernews: soft budget exceeded")
+
+// ErrByteBudgetExceeded is returned once the client has read more response
+// bytes than the budget set with WithByteBudget allows.
+var ErrByteBudgetExceeded = errors.New("hackernews: byte budget exceeded")
+
+// ErrSnapshotVersion is returned by LoadSnapshot when the snapshot was written
+// with an incompatible schema version.
+var ErrSnapshotVersion = errors.New("hackernews: incompatible snapshot version")
+
+// ErrRateLimited matches an *APIError for a 429 response, so callers can back
+// off with errors.Is(err, ErrRateLimited).
+var ErrRateLimited = errors.New("hackernews: rate limited")
+
+// ErrNotFound matches an *APIError for a 404 response, e.g. from Find when the
+// item doesn't exist. Use errors.As to get at the *APIError and its body.
+var ErrNotFound = errors.New("hackernews: not found")
+
+// APIError is returned when Algolia responds with an unexpected status code.
+// RetryAfter is how long the server asked us to wait, when it said.
+type APIError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// Is lets errors.Is match the sentinel errors for specific status codes
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	default:
+		return false
+	}
+}
+
+// retryAfter parses the Retry-After header, which is either a number of
+// seconds or an HTTP date
+func retryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	date, err := http.ParseTime(header)
+	if err != nil || !date.After(now) {
+		return 0
+	}
+	return date.Sub(now)
+}
+
+// RetryError is returned when retries are enabled with WithRetry and a request
+// still failed. It records how many attempts were made. Only failures report
+// the attempts.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("hackernews: failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// FindError is returned by FindMany when some of the items couldn't be found.
+// Errors maps each failed id to its error.
+type FindError struct {
+	Errors map[int]error
+}
+
+func (e *FindError) Error() string {
+	ids := make([]int, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	messages := make([]string, len(ids))
+	for i, id := range ids {
+		messages[i] = fmt.Sprintf("%d: %v", id, e.Errors[id])
+	}
+	return fmt.Sprintf("hackernews: failed to find %s: %s", plural(len(ids), "item", "items"), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the error for the lowest failed id, so errors.Is and
+// errors.As work on the error when a single lookup failed
+func (e *FindError) Unwrap() error {
+	lowest := 0
+	for id := range e.Errors {
+		if lowest == 0 || id < lowest {
+			lowest = id
+		}
+	}
+	return e.Errors[lowest]
+}
@@ -0,0 +1,7 @@
+package hackernews
+
+// ProcessChildren exposes the filtering, sorting and decoding that Find does
+// to the comment tree, so it can be tested without decoding JSON
+func (c *Client) ProcessChildren(children []Children) []Children {
+	return c.processChildren(children)
+}
@@ -0,0 +1,54 @@
+package hackernews
+
+import (
+	"strconv"
+	"time"
+)
+
+// NumericFilter is a condition on the points, creation time or number of
+// comments of the results. Build them with helpers like PointsAtLeast and
+// combine as many as you like in SearchRequest.Filters.
+type NumericFilter string
+
+func numericFilter(key, op string, value int64) NumericFilter {
+	return NumericFilter(key + op + strconv.FormatInt(value, 10))
+}
+
+// between joins the lower and upper bound of a range
+func between(min, max NumericFilter) NumericFilter {
+	return min + "," + max
+}
+
+// PointsAtLeast matches results with n or more points
+func PointsAtLeast(n int) NumericFilter {
+	return numericFilter("points", ">=", int64(n))
+}
+
+// PointsAtMost matches results with n or fewer points
+func PointsAtMost(n int) NumericFilter {
+	return numericFilter("points", "<=", int64(n))
+}
+
+// PointsBetween matches results with min to max points, inclusive
+func PointsBetween(min, max int) NumericFilter {
+	return between(PointsAtLeast(min), PointsAtMost(max))
+}
+
+// CommentsAtLeast matches results with n or more comments
+func CommentsAtLeast(n int) NumericFilter {
+	return numericFilter("num_comments", ">=", int64(n))
+}
+
+// CommentsAtMost matches results with n or fewer comments
+func CommentsAtMost(n int) NumericFilter {
+	return numericFilter("num_comments", "<=", int64(n))
+}
+
+// CreatedBetween matches results created from start up to, but not including,
+// end. Times are rounded down to the second.
+func CreatedBetween(start, end time.Time) NumericFilter {
+	return between(
+		numericFilter("created_at_i", ">=", start.Unix()),
+		numericFilter("created_at_i", "<", end.Unix()),
+	)
+}
@@ -0,0 +1,79 @@
+package hackernews_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestNumericFilters(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		filters  []hackernews.NumericFilter
+		expected string
+	}{
+		{[]hackernews.NumericFilter{hackernews.PointsAtLeast(500)}, "points>=500"},
+		{[]hackernews.NumericFilter{hackernews.PointsAtMost(10)}, "points<=10"},
+		{[]hackernews.NumericFilter{hackernews.PointsBetween(10, 20)}, "points>=10,points<=20"},
+		{[]hackernews.NumericFilter{hackernews.CommentsAtLeast(1)}, "num_comments>=1"},
+		{[]hackernews.NumericFilter{hackernews.CommentsAtMost(0)}, "num_comments<=0"},
+		{[]hackernews.NumericFilter{hackernews.CreatedBetween(start, end)}, "created_at_i>=1609459200,created_at_i<1612137600"},
+		{
+			[]hackernews.NumericFilter{hackernews.PointsAtLeast(100), hackernews.CommentsAtLeast(50), hackernews.CreatedBetween(start, end)},
+			"points>=100,num_comments>=50,created_at_i>=1609459200,created_at_i<1612137600",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			is := is.New(t)
+			search := &hackernews.SearchRequest{Filters: test.filters}
+			query, err := url.ParseQuery(search.EncodeQuery())
+			is.NoErr(err)
+			is.Equal(query.Get("numericFilters"), test.expected)
+		})
+	}
+}
+
+func TestNumericFiltersWithStrings(t *testing.T) {
+	is := is.New(t)
+	search := &hackernews.SearchRequest{
+		Points:  "> 5",
+		Filters: []hackernews.NumericFilter{hackernews.CommentsAtLeast(3)},
+	}
+	query, err := url.ParseQuery(search.EncodeQuery())
+	is.NoErr(err)
+	is.Equal(query.Get("numericFilters"), "points>5,num_comments>=3")
+	// Parsing moves the filters into the string fields
+	parsed, err := hackernews.ParseSearchRequest(search.EncodeQuery())
+	is.NoErr(err)
+	is.Equal(parsed.Points, "points>5")
+	is.Equal(parsed.NumComments, "num_comments>=3")
+	is.Equal(parsed.EncodeQuery(), search.EncodeQuery())
+}
+
+func TestCreatedAfterBefore(t *testing.T) {
+	after := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		search   hackernews.SearchRequest
+		expected string
+	}{
+		{hackernews.SearchRequest{}, ""},
+		{hackernews.SearchRequest{CreatedAfter: after}, "created_at_i>1609459200"},
+		{hackernews.SearchRequest{CreatedBefore: before}, "created_at_i<1612137600"},
+		{hackernews.SearchRequest{CreatedAfter: after, CreatedBefore: before}, "created_at_i>1609459200,created_at_i<1612137600"},
+		{hackernews.SearchRequest{Points: ">1", CreatedAfter: after.Add(500 * time.Millisecond)}, "points>1,created_at_i>1609459200"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			is := is.New(t)
+			query, err := url.ParseQuery(test.search.EncodeQuery())
+			is.NoErr(err)
+			is.Equal(query.Get("numericFilters"), test.expected)
+		})
+	}
+}
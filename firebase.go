@@ -0,0 +1,73 @@
+package hackernews
+
+import (
+	"context"
+	"fmt"
+)
+
+// defaultFirebaseURL is HN's official API. Unlike Algolia, it has the ranked
+// story lists.
+const defaultFirebaseURL = `https://hacker-news.firebaseio.com/v0`
+
+// FirebaseItem is an item from HN's official Firebase API. Its fields are named
+// after the API's, which differ from Algolia's.
+type FirebaseItem struct {
+	ID          int    `json:"id"`
+	Deleted     bool   `json:"deleted,omitempty"`
+	Type        string `json:"type,omitempty"`
+	By          string `json:"by,omitempty"`
+	Time        int64  `json:"time,omitempty"`
+	Text        string `json:"text,omitempty"`
+	Dead        bool   `json:"dead,omitempty"`
+	Parent      int    `json:"parent,omitempty"`
+	Poll        int    `json:"poll,omitempty"`
+	Kids        []int  `json:"kids,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Score       int    `json:"score,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Parts       []int  `json:"parts,omitempty"`
+	Descendants int    `json:"descendants,omitempty"`
+}
+
+// TopStories returns the ids of the stories on the front page, in the order HN
+// ranks them.
+func (c *Client) TopStories(ctx context.Context) ([]int, error) {
+	return c.storyList(ctx, "topstories")
+}
+
+// BestStories returns the ids of the best recent stories, in order.
+func (c *Client) BestStories(ctx context.Context) ([]int, error) {
+	return c.storyList(ctx, "beststories")
+}
+
+// NewStories returns the ids of the newest stories, newest first.
+func (c *Client) NewStories(ctx context.Context) ([]int, error) {
+	return c.storyList(ctx, "newstories")
+}
+
+func (c *Client) storyList(ctx context.Context, list string) ([]int, error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.findTimeout)
+	defer cancel()
+	ids := []int{}
+	if err := c.getWithHeaders(ctx, c.firebaseURL+"/"+list+".json", nil, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// FindFirebase finds an item with HN's Firebase API. Comments aren't included,
+// only their ids in Kids. It returns an error matching ErrNotFound when there's
+// no such item.
+func (c *Client) FindFirebase(ctx context.Context, id int) (*FirebaseItem, error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.findTimeout)
+	defer cancel()
+	var item *FirebaseItem
+	if err := c.getWithHeaders(ctx, fmt.Sprintf("%s/item/%d.json", c.firebaseURL, id), nil, &item); err != nil {
+		return nil, err
+	}
+	// Firebase responds with null for items that don't exist
+	if item == nil {
+		return nil, fmt.Errorf("%w: item %d", ErrNotFound, id)
+	}
+	return item, nil
+}
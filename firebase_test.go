@@ -0,0 +1,71 @@
+package hackernews_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+// fakeFirebase returns a client that sends Firebase requests to handler
+func fakeFirebase(t testing.TB, handler http.Handler, options ...hackernews.Option) *hackernews.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	options = append(options, hackernews.WithFirebaseBaseURL(server.URL+"/v0/"))
+	return hackernews.New(options...)
+}
+
+func TestStoryLists(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeFirebase(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v0/topstories.json":
+			fmt.Fprint(w, `[3,1,2]`)
+		case "/v0/beststories.json":
+			fmt.Fprint(w, `[2,3]`)
+		case "/v0/newstories.json":
+			fmt.Fprint(w, `[3,2,1]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	top, err := hn.TopStories(ctx)
+	is.NoErr(err)
+	is.Equal(top, []int{3, 1, 2}) // ranked order is kept
+	best, err := hn.BestStories(ctx)
+	is.NoErr(err)
+	is.Equal(best, []int{2, 3})
+	newest, err := hn.NewStories(ctx)
+	is.NoErr(err)
+	is.Equal(newest, []int{3, 2, 1})
+}
+
+func TestFindFirebase(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var apiKey []string
+	hn := fakeFirebase(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey = r.Header.Values("X-Algolia-API-Key")
+		switch r.URL.Path {
+		case "/v0/item/8863.json":
+			fmt.Fprint(w, `{"by":"dhouston","descendants":71,"id":8863,"kids":[9224,8917],"score":104,"time":1175714200,"title":"My YC app: Dropbox","type":"story","url":"http://www.getdropbox.com/u/2/screencast.html"}`)
+		default:
+			fmt.Fprint(w, `null`)
+		}
+	}), hackernews.WithAlgoliaCredentials("app", "secret"))
+	item, err := hn.FindFirebase(ctx, 8863)
+	is.NoErr(err)
+	is.Equal(item.ID, 8863)
+	is.Equal(item.By, "dhouston")
+	is.Equal(item.Kids, []int{9224, 8917})
+	is.Equal(item.Descendants, 71)
+	is.Equal(len(apiKey), 0) // Algolia credentials aren't sent to Firebase
+	_, err = hn.FindFirebase(ctx, 1)
+	is.True(errors.Is(err, hackernews.ErrNotFound))
+}
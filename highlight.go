@@ -0,0 +1,59 @@
+package hackernews
+
+import "strings"
+
+// Algolia wraps the words that matched the query in these tags
+const (
+	highlightStart = "<em>"
+	highlightEnd   = "</em>"
+)
+
+var (
+	ansiHighlighter  = strings.NewReplacer(highlightStart, "\x1b[1m", highlightEnd, "\x1b[0m")
+	highlightRemover = strings.NewReplacer(highlightStart, "", highlightEnd, "")
+)
+
+// HTML returns the highlighted value as Algolia sent it, with the matched words
+// wrapped in <em> tags.
+func (h Highlight) HTML() string {
+	return h.Value
+}
+
+// ANSI returns the highlighted value as plain text for terminals, with the
+// matched words in bold. See PlainText for how the HTML is converted.
+func (h Highlight) ANSI() string {
+	return plainText(ansiHighlighter.Replace(h.Value))
+}
+
+// StripHighlights returns the highlighted value as plain text, without the
+// <em> markers, other tags or HTML entities.
+func (h Highlight) StripHighlights() string {
+	return plainText(highlightRemover.Replace(h.Value))
+}
+
+// matchLevels ranks Algolia's match levels from weakest to strongest
+var matchLevels = map[string]int{
+	"none":    0,
+	"partial": 1,
+	"full":    2,
+}
+
+// BestMatchLevel returns the strongest match level across all the highlighted
+// attributes: "full", "partial" or "none".
+func (h *Hit) BestMatchLevel() string {
+	best := "none"
+	for _, highlight := range []Highlight{
+		h.Highlights.Title,
+		h.Highlights.URL,
+		h.Highlights.Author,
+		h.Highlights.StoryText,
+		h.Highlights.CommentText,
+		h.Highlights.StoryTitle,
+		h.Highlights.StoryURL,
+	} {
+		if matchLevels[highlight.MatchLevel] > matchLevels[best] {
+			best = highlight.MatchLevel
+		}
+	}
+	return best
+}
@@ -0,0 +1,69 @@
+package hackernews_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestBestMatchLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		hit      string
+		expected string
+	}{
+		{"full", `{"_highlightResult":{
+			"title":{"matchLevel":"partial"},
+			"url":{"matchLevel":"full"},
+			"author":{"matchLevel":"none"}
+		}}`, "full"},
+		{"partial", `{"_highlightResult":{
+			"title":{"matchLevel":"none"},
+			"story_text":{"matchLevel":"partial"}
+		}}`, "partial"},
+		{"none", `{"_highlightResult":{
+			"title":{"matchLevel":"none"},
+			"author":{"matchLevel":"none"}
+		}}`, "none"},
+		{"comment", `{"_highlightResult":{
+			"author":{"matchLevel":"none"},
+			"comment_text":{"matchLevel":"full"},
+			"story_title":{"matchLevel":"partial"}
+		}}`, "full"},
+		{"comment story", `{"_highlightResult":{
+			"comment_text":{"matchLevel":"none"},
+			"story_url":{"matchLevel":"partial"}
+		}}`, "partial"},
+		{"missing", `{}`, "none"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+			hit := new(hackernews.Hit)
+			is.NoErr(json.Unmarshal([]byte(test.hit), hit))
+			is.Equal(hit.BestMatchLevel(), test.expected)
+		})
+	}
+}
+
+func TestHighlightFormats(t *testing.T) {
+	is := is.New(t)
+	highlight := hackernews.Highlight{Value: "Show HN: <em>Go</em> in the <em>browser</em>"}
+	is.Equal(highlight.HTML(), "Show HN: <em>Go</em> in the <em>browser</em>")
+	is.Equal(highlight.ANSI(), "Show HN: \x1b[1mGo\x1b[0m in the \x1b[1mbrowser\x1b[0m")
+	is.Equal(highlight.StripHighlights(), "Show HN: Go in the browser")
+	is.Equal(hackernews.Highlight{}.ANSI(), "")
+}
+
+func TestHighlightCommentText(t *testing.T) {
+	is := is.New(t)
+	hit := new(hackernews.Hit)
+	is.NoErr(json.Unmarshal([]byte(`{"_highlightResult":{
+		"comment_text":{"value":"it&#x27;s <em>go</em> <p>time &amp; <a href=\"https:&#x2F;&#x2F;go.dev\">space</a>","matchLevel":"full"}
+	}}`), hit))
+	highlight := hit.Highlights.CommentText
+	is.Equal(highlight.StripHighlights(), "it's go \n\ntime & space")
+	is.Equal(highlight.ANSI(), "it's \x1b[1mgo\x1b[0m \n\ntime & space")
+}
@@ -0,0 +1,26 @@
+package hackernews
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// PointsHistogram fetches every story matching the search request with
+// SearchAll and sums their points into buckets of the given size by creation time. Each bucket is
+// keyed by the time it starts at in UTC. When the soft budget runs out, the
+// histogram so far is returned with ErrBudgetExceeded.
+func (c *Client) PointsHistogram(ctx context.Context, req *SearchRequest, bucket time.Duration) (map[time.Time]int, error) {
+	if bucket <= 0 {
+		return nil, errors.New("hackernews: histogram bucket must be positive")
+	}
+	stories, err := c.SearchAll(ctx, req)
+	if err != nil && !errors.Is(err, ErrBudgetExceeded) {
+		return nil, err
+	}
+	histogram := map[time.Time]int{}
+	for _, story := range stories {
+		histogram[story.createdAt().Truncate(bucket)] += story.Points
+	}
+	return histogram, err
+}
@@ -0,0 +1,51 @@
+package hackernews_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestPointsHistogram(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	pages := []string{
+		`{"page":0,"nbPages":2,"hits":[
+			{"objectID":"1","points":10,"created_at_i":1700000000},
+			{"objectID":"2","points":5,"created_at_i":1700001000}
+		]}`,
+		`{"page":1,"nbPages":2,"hits":[
+			{"objectID":"2","points":5,"created_at_i":1700001000},
+			{"objectID":"3","points":7,"created_at_i":1700003700},
+			{"objectID":"4","points":1,"created_at_i":1700010800}
+		]}`,
+	}
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, pages[1])
+		default:
+			fmt.Fprint(w, pages[0])
+		}
+	}))
+	histogram, err := hn.PointsHistogram(ctx, &hackernews.SearchRequest{Tags: "story"}, time.Hour)
+	is.NoErr(err)
+	is.Equal(histogram, map[time.Time]int{
+		time.Date(2023, 11, 14, 22, 0, 0, 0, time.UTC): 15, // 22:13:20 and 22:30:00, counted once
+		time.Date(2023, 11, 14, 23, 0, 0, 0, time.UTC): 7,  // 23:15:00
+		time.Date(2023, 11, 15, 1, 0, 0, 0, time.UTC):  1,  // 01:13:20 the next day
+	})
+}
+
+func TestPointsHistogramInvalidBucket(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.NotFoundHandler())
+	_, err := hn.PointsHistogram(ctx, &hackernews.SearchRequest{}, 0)
+	is.True(err != nil)
+}
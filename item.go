@@ -0,0 +1,132 @@
+package hackernews
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Item is anything FindItem can return: a *Story for stories and jobs, a
+// *Children for comments or a *Poll for polls.
+type Item interface {
+	item()
+}
+
+func (*Story) item()    {}
+func (*Children) item() {}
+func (*Poll) item()     {}
+
+// Poll is a story with options to vote on
+type Poll struct {
+	Story
+	Options []PollOption `json:"options"`
+}
+
+// PollOption is one of the options on a poll
+type PollOption struct {
+	ID     int     `json:"id,omitempty"`
+	Text   *string `json:"text,omitempty"`
+	Points *int    `json:"points,omitempty"`
+}
+
+// UnmarshalJSON decodes the options alongside the embedded story
+func (p *Poll) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &p.Story); err != nil {
+		return err
+	}
+	var options struct {
+		Options []PollOption `json:"options"`
+	}
+	if err := json.Unmarshal(data, &options); err != nil {
+		return err
+	}
+	p.Options = options.Options
+	return nil
+}
+
+// collectOptions moves any poll options that came back as children into
+// Options, so they aren't mistaken for comments. Options are ordered by id,
+// which is the order they're listed in on HN.
+func (p *Poll) collectOptions() {
+	seen := map[int]bool{}
+	for _, option := range p.Options {
+		seen[option.ID] = true
+	}
+	children := p.Children[:0]
+	for _, child := range p.Children {
+		if child.Type != "pollopt" {
+			children = append(children, child)
+			continue
+		}
+		if !seen[child.ID] {
+			seen[child.ID] = true
+			p.Options = append(p.Options, PollOption{ID: child.ID, Text: child.Text, Points: child.Points})
+		}
+	}
+	p.Children = children
+	sort.SliceStable(p.Options, func(i, j int) bool {
+		return p.Options[i].ID < p.Options[j].ID
+	})
+}
+
+// FindPoll finds a poll by its id, along with its options and their points. It
+// returns an error when the item isn't a poll.
+func (c *Client) FindPoll(ctx context.Context, id int) (*Poll, error) {
+	item, err := c.FindItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	poll, ok := item.(*Poll)
+	if !ok {
+		return nil, fmt.Errorf("hackernews: item %d is not a poll", id)
+	}
+	return poll, nil
+}
+
+// FindItem finds an item by its id and returns it as the right type based on
+// the item's type. Use a type switch to tell them apart:
+//
+//	switch item := item.(type) {
+//	case *hackernews.Story:
+//	case *hackernews.Children:
+//	case *hackernews.Poll:
+//	}
+func (c *Client) FindItem(ctx context.Context, id int) (Item, error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.findTimeout)
+	defer cancel()
+	var raw json.RawMessage
+	if err := c.get(ctx, fmt.Sprintf("%s/items/%d", c.baseURL, id), &raw); err != nil {
+		return nil, err
+	}
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, err
+	}
+	switch header.Type {
+	case "comment":
+		comment := new(Children)
+		if err := json.Unmarshal(raw, comment); err != nil {
+			return nil, err
+		}
+		comment.Children = c.processChildren(comment.Children)
+		return comment, nil
+	case "poll":
+		poll := new(Poll)
+		if err := json.Unmarshal(raw, poll); err != nil {
+			return nil, err
+		}
+		poll.collectOptions()
+		poll.Children = c.processChildren(poll.Children)
+		return poll, nil
+	default:
+		story := new(Story)
+		if err := json.Unmarshal(raw, story); err != nil {
+			return nil, err
+		}
+		story.Children = c.processChildren(story.Children)
+		return story, nil
+	}
+}
@@ -0,0 +1,112 @@
+package hackernews_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+// serveItems serves the item fixtures in testdata by id
+func serveItems(t testing.TB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/items/100":
+			http.ServeFile(w, r, "testdata/item.json")
+		case "/api/v1/items/101":
+			http.ServeFile(w, r, "testdata/comment.json")
+		case "/api/v1/items/200":
+			http.ServeFile(w, r, "testdata/poll.json")
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestFindItemStory(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveItems(t))
+	item, err := hn.FindItem(ctx, 100)
+	is.NoErr(err)
+	story, ok := item.(*hackernews.Story)
+	is.True(ok) // item is a story
+	is.Equal(story.Title, "Ask HN: What's your favorite editor?")
+	is.Equal(commentIDs(story.Children), []int{102, 101}) // filtered and sorted
+}
+
+func TestFindItemComment(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveItems(t))
+	item, err := hn.FindItem(ctx, 101)
+	is.NoErr(err)
+	comment, ok := item.(*hackernews.Children)
+	is.True(ok) // item is a comment
+	is.Equal(*comment.Author, "bob")
+	is.Equal(comment.StoryID, 100)
+	is.Equal(commentIDs(comment.Children), []int{104, 103}) // sorted
+}
+
+func TestFindItemPoll(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveItems(t))
+	item, err := hn.FindItem(ctx, 200)
+	is.NoErr(err)
+	poll, ok := item.(*hackernews.Poll)
+	is.True(ok) // item is a poll
+	is.Equal(poll.Title, "Poll: Tabs or spaces?")
+	is.Equal(poll.Points, 120)
+	is.Equal(len(poll.Options), 2)
+	is.Equal(*poll.Options[0].Text, "Tabs")
+	is.Equal(*poll.Options[0].Points, 85)
+	is.Equal(*poll.Options[1].Text, "Spaces")
+	is.Equal(len(poll.Children), 1)
+}
+
+func TestFindItemNotFound(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveItems(t))
+	_, err := hn.FindItem(ctx, 999)
+	is.True(errors.Is(err, hackernews.ErrNotFound))
+}
+
+func TestFindPoll(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveItems(t))
+	poll, err := hn.FindPoll(ctx, 200)
+	is.NoErr(err)
+	is.Equal(poll.Title, "Poll: Tabs or spaces?")
+	is.Equal(len(poll.Options), 2)
+	is.Equal(poll.Options[0].ID, 201)
+	is.Equal(*poll.Options[0].Points, 85)
+	is.Equal(poll.Options[1].ID, 202)
+	_, err = hn.FindPoll(ctx, 100)
+	is.True(err != nil) // not a poll
+}
+
+func TestFindPollOptionsInChildren(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"type":"poll","title":"Which?","author":"a","children":[
+			{"id":4,"type":"comment","author":"b","text":"Neither","children":[]},
+			{"id":3,"type":"pollopt","author":"a","text":"B","points":7,"children":[]},
+			{"id":2,"type":"pollopt","author":"a","text":"A","points":5,"children":[]}
+		],"options":[{"id":2,"text":"A","points":5}]}`)
+	}))
+	poll, err := hn.FindPoll(ctx, 1)
+	is.NoErr(err)
+	is.Equal(len(poll.Options), 2)
+	is.Equal(*poll.Options[0].Text, "A")
+	is.Equal(*poll.Options[1].Text, "B")
+	is.Equal(*poll.Options[1].Points, 7)
+	is.Equal(commentIDs(poll.Children), []int{4}) // options aren't comments
+}
@@ -0,0 +1,139 @@
+package hackernews
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// LinkKind is a rough classification of what a story links to. It's useful
+// for picking an icon without fetching the page.
+type LinkKind int
+
+const (
+	// LinkOther is a story without a URL or a link we can't classify
+	LinkOther LinkKind = iota
+	// LinkArticle is a regular web page
+	LinkArticle
+	// LinkPDF is a link to a PDF document
+	LinkPDF
+	// LinkVideo is a link to a video or video hosting site
+	LinkVideo
+	// LinkGitHub is a link to a GitHub repository, gist or page
+	LinkGitHub
+	// LinkTweet is a link to Twitter (or X)
+	LinkTweet
+)
+
+func (k LinkKind) String() string {
+	switch k {
+	case LinkArticle:
+		return "article"
+	case LinkPDF:
+		return "pdf"
+	case LinkVideo:
+		return "video"
+	case LinkGitHub:
+		return "github"
+	case LinkTweet:
+		return "tweet"
+	default:
+		return "other"
+	}
+}
+
+var videoHosts = map[string]bool{
+	"youtube.com":   true,
+	"m.youtube.com": true,
+	"youtu.be":      true,
+	"vimeo.com":     true,
+	"twitch.tv":     true,
+}
+
+var videoExtensions = map[string]bool{
+	".mp4":  true,
+	".webm": true,
+	".mov":  true,
+	".mkv":  true,
+}
+
+var tweetHosts = map[string]bool{
+	"twitter.com":        true,
+	"mobile.twitter.com": true,
+	"x.com":              true,
+}
+
+// LinkKind classifies the story's URL based on its host and extension. No
+// network requests are made.
+func (s *Story) LinkKind() LinkKind {
+	return linkKind(s.URL)
+}
+
+func linkKind(rawURL string) LinkKind {
+	if rawURL == "" {
+		return LinkOther
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return LinkOther
+	}
+	host := hostname(u)
+	switch {
+	case host == "github.com" || host == "gist.github.com" || strings.HasSuffix(host, ".github.io"):
+		return LinkGitHub
+	case tweetHosts[host]:
+		return LinkTweet
+	case videoHosts[host]:
+		return LinkVideo
+	}
+	ext := strings.ToLower(path.Ext(u.Path))
+	switch {
+	case ext == ".pdf":
+		return LinkPDF
+	case videoExtensions[ext]:
+		return LinkVideo
+	}
+	return LinkArticle
+}
+
+// Domain returns the story's host without a leading "www.", like the domain
+// shown next to the title on Hacker News (e.g. "github.com"). It's empty for
+// stories without a URL (e.g. Ask HN) or with a URL that can't be parsed.
+func (s *Story) Domain() string {
+	return domain(s.URL)
+}
+
+// Domain returns the hit's host without a leading "www.". See Story.Domain.
+func (h *Hit) Domain() string {
+	return domain(h.URL)
+}
+
+func domain(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return hostname(u)
+}
+
+// hostname lowercases the URL's host and drops the port and "www."
+func hostname(u *url.URL) string {
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// itemURL is the discussion page for an item on Hacker News
+const itemURL = "https://news.ycombinator.com/item?id="
+
+// HNURL returns the link to the story's discussion on Hacker News
+func (s *Story) HNURL() string {
+	return itemURL + strconv.Itoa(s.ID)
+}
+
+// HNURL returns the link to the comment on Hacker News
+func (c *Children) HNURL() string {
+	return itemURL + strconv.Itoa(c.ID)
+}
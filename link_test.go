@@ -0,0 +1,71 @@
+package hackernews_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestLinkKind(t *testing.T) {
+	tests := []struct {
+		url  string
+		kind hackernews.LinkKind
+	}{
+		{"", hackernews.LinkOther},
+		{"not a url\x7f", hackernews.LinkOther},
+		{"ftp://example.com/file.txt", hackernews.LinkOther},
+		{"https://example.com/blog/post", hackernews.LinkArticle},
+		{"http://www.paulgraham.com/avg.html", hackernews.LinkArticle},
+		{"https://arxiv.org/pdf/1706.03762.PDF", hackernews.LinkPDF},
+		{"https://example.com/paper.pdf?download=1", hackernews.LinkPDF},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", hackernews.LinkVideo},
+		{"https://youtu.be/dQw4w9WgXcQ", hackernews.LinkVideo},
+		{"https://vimeo.com/123456", hackernews.LinkVideo},
+		{"https://example.com/demo.mp4", hackernews.LinkVideo},
+		{"https://github.com/matthewmueller/hackernews", hackernews.LinkGitHub},
+		{"https://gist.github.com/someone/abc123", hackernews.LinkGitHub},
+		{"https://someone.github.io/project/", hackernews.LinkGitHub},
+		{"https://twitter.com/paulg/status/1", hackernews.LinkTweet},
+		{"https://x.com/paulg/status/1", hackernews.LinkTweet},
+	}
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			is := is.New(t)
+			story := &hackernews.Story{URL: test.url}
+			is.Equal(story.LinkKind(), test.kind)
+		})
+	}
+}
+
+func TestDomain(t *testing.T) {
+	tests := []struct {
+		url    string
+		domain string
+	}{
+		{"", ""},
+		{"not a url\x7f", ""},
+		{"/relative/path", ""},
+		{"https://github.com/matthewmueller/hackernews", "github.com"},
+		{"http://www.paulgraham.com/avg.html", "paulgraham.com"},
+		{"https://WWW.Example.COM:8080/path", "example.com"},
+		{"https://blog.example.co.uk/post", "blog.example.co.uk"},
+	}
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			is := is.New(t)
+			story := &hackernews.Story{URL: test.url}
+			is.Equal(story.Domain(), test.domain)
+			hit := &hackernews.Hit{URL: test.url}
+			is.Equal(hit.Domain(), test.domain)
+		})
+	}
+}
+
+func TestHNURL(t *testing.T) {
+	is := is.New(t)
+	story := &hackernews.Story{ID: 8863, URL: "http://www.getdropbox.com/u/2/screencast.html"}
+	is.Equal(story.HNURL(), "https://news.ycombinator.com/item?id=8863")
+	comment := &hackernews.Children{ID: 9224}
+	is.Equal(comment.HNURL(), "https://news.ycombinator.com/item?id=9224")
+}
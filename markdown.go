@@ -0,0 +1,143 @@
+package hackernews
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlTag  = regexp.MustCompile(`<(/?)([a-zA-Z]+)([^>]*)>`)
+	hrefAttr = regexp.MustCompile(`(?i)href\s*=\s*"([^"]*)"`)
+)
+
+// Markdown converts the comment's HTML into Markdown. Paragraphs are separated
+// by a blank line, links become [text](url), italics become *text*, inline
+// code is wrapped in backticks and <pre> blocks are fenced. Other tags are
+// dropped and unclosed tags are closed at the end.
+func (c *Children) Markdown() string {
+	if c.Text == nil {
+		return ""
+	}
+	return markdown(*c.Text)
+}
+
+// Markdown converts the story's HTML text into Markdown. See
+// Children.Markdown.
+func (s *Story) Markdown() string {
+	if s.Text == nil {
+		return ""
+	}
+	return markdown(*s.Text)
+}
+
+// openLink is an <a> that hasn't been closed yet
+type openLink struct {
+	href  string
+	start int
+}
+
+func markdown(body string) string {
+	var (
+		out     []byte
+		links   []openLink
+		italics int
+		inPre   bool
+		inCode  bool
+	)
+	text := func(s string) {
+		out = append(out, html.UnescapeString(s)...)
+	}
+	// trimRight trims the end of out, but never into the label of a link that's
+	// still open, since the label starts at a fixed offset
+	trimRight := func(cutset string) {
+		floor := 0
+		if len(links) > 0 {
+			floor = links[len(links)-1].start
+		}
+		out = append(out[:floor], strings.TrimRight(string(out[floor:]), cutset)...)
+	}
+	paragraph := func() {
+		trimRight(" \n")
+		if len(out) > 0 {
+			out = append(out, "\n\n"...)
+		}
+	}
+	last := 0
+	for _, match := range htmlTag.FindAllStringSubmatchIndex(body, -1) {
+		text(body[last:match[0]])
+		last = match[1]
+		closing := match[3] > match[2]
+		name := strings.ToLower(body[match[4]:match[5]])
+		attrs := body[match[6]:match[7]]
+		if inPre && !(closing && name == "pre") {
+			// Code blocks are taken literally, apart from the <code> inside
+			if name != "code" {
+				text(body[match[0]:match[1]])
+			}
+			continue
+		}
+		switch {
+		case name == "p" || name == "br":
+			paragraph()
+		case name == "pre" && !closing:
+			paragraph()
+			out = append(out, "```\n"...)
+			inPre = true
+		case name == "pre" && closing:
+			trimRight("\n")
+			out = append(out, "\n```\n\n"...)
+			inPre = false
+		case name == "code":
+			if closing == inCode {
+				out = append(out, '`')
+				inCode = !inCode
+			}
+		case name == "i" || name == "em":
+			if !closing {
+				out = append(out, '*')
+				italics++
+			} else if italics > 0 {
+				out = append(out, '*')
+				italics--
+			}
+		case name == "a" && !closing:
+			href := ""
+			if m := hrefAttr.FindStringSubmatch(attrs); m != nil {
+				href = html.UnescapeString(m[1])
+			}
+			links = append(links, openLink{href, len(out)})
+		case name == "a" && closing && len(links) > 0:
+			l := links[len(links)-1]
+			links = links[:len(links)-1]
+			label := string(out[l.start:])
+			out = append(out[:l.start], markdownLink(label, l.href)...)
+		}
+	}
+	text(body[last:])
+	// Close anything that was left open
+	if inCode {
+		out = append(out, '`')
+	}
+	for ; italics > 0; italics-- {
+		out = append(out, '*')
+	}
+	if inPre {
+		trimRight("\n")
+		out = append(out, "\n```"...)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// markdownLink formats a link, leaving bare URLs as they are. HN shortens the
+// text of long links with "...", so those are treated as bare URLs too.
+func markdownLink(label, href string) string {
+	label = strings.TrimSpace(label)
+	if href == "" {
+		return label
+	}
+	if label == "" || label == href || strings.HasPrefix(href, strings.TrimSuffix(label, "...")) {
+		return href
+	}
+	return "[" + label + "](" + href + ")"
+}
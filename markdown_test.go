@@ -0,0 +1,47 @@
+package hackernews_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		md   string
+	}{
+		{"empty", "", ""},
+		{"text", "Isn&#x27;t it &gt; 1?", "Isn't it > 1?"},
+		{"paragraphs", "First<p>Second<p>Third", "First\n\nSecond\n\nThird"},
+		{"italic", "It&#x27;s <i>really</i> fast", "It's *really* fast"},
+		{"link", `See <a href="https:&#x2F;&#x2F;go.dev&#x2F;doc" rel="nofollow">the docs</a>.`, "See [the docs](https://go.dev/doc)."},
+		{"bare link", `<a href="https:&#x2F;&#x2F;go.dev" rel="nofollow">https:&#x2F;&#x2F;go.dev</a>`, "https://go.dev"},
+		{"shortened link", `<a href="https:&#x2F;&#x2F;example.com&#x2F;a&#x2F;very&#x2F;long&#x2F;path" rel="nofollow">https:&#x2F;&#x2F;example.com&#x2F;a&#x2F;very...</a>`, "https://example.com/a/very/long/path"},
+		{"inline code", "Use <code>go vet</code> first", "Use `go vet` first"},
+		{"code block", "Try this:<p><pre><code>  if x &lt; 1 {\n    return\n  }\n</code></pre>Done.", "Try this:\n\n```\n  if x < 1 {\n    return\n  }\n```\n\nDone."},
+		{"nested", `<i>see <a href="https://a.com">this</a></i>`, "*see [this](https://a.com)*"},
+		{"unclosed italic", "<i>forever", "*forever*"},
+		{"unclosed link", `<a href="https://a.com">dangling`, "dangling"},
+		{"unclosed code block", "<pre><code>x := 1", "```\nx := 1\n```"},
+		{"unknown tag", "<b>bold</b> move", "bold move"},
+		{"paragraph in link", `foo   <a href="u"><p></a>`, "foo   u"},
+		{"code block in link", `x <a href="u"><pre>y</pre></a>`, "x [```\ny\n```](u)"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+			comment := &hackernews.Children{Text: &test.html}
+			is.Equal(comment.Markdown(), test.md)
+			story := &hackernews.Story{Text: &test.html}
+			is.Equal(story.Markdown(), test.md)
+		})
+	}
+}
+
+func TestMarkdownNil(t *testing.T) {
+	is := is.New(t)
+	is.Equal((&hackernews.Children{}).Markdown(), "")
+}
@@ -0,0 +1,79 @@
+package hackernews
+
+import "time"
+
+// NormalizedStory is a Story without pointer fields. Missing values are left at
+// their zero value.
+type NormalizedStory struct {
+	ID          int
+	CreatedAt   time.Time
+	CreatedAtI  int
+	Type        string
+	Author      string
+	Title       string
+	URL         string
+	Text        string
+	NumComments int
+	Points      int
+	ParentID    int
+	StoryID     int
+	Children    []Children
+
+	// Tags, StoryTitle, StoryURL and RelevancyScore are only set for search
+	// results. See Story.
+	Tags           []string
+	StoryTitle     string
+	StoryURL       string
+	RelevancyScore int
+
+	// HasText is true when the story came with a text body
+	HasText bool
+
+	// HasComments is true when the story came with a comment count
+	HasComments bool
+
+	// HasRelevancyScore is true when the story came with a relevancy score
+	HasRelevancyScore bool
+}
+
+// Normalized returns a copy of the story with its pointer fields resolved, for
+// callers who prefer value semantics over nil checks.
+func (s *Story) Normalized() NormalizedStory {
+	normalized := NormalizedStory{
+		ID:         s.ID,
+		CreatedAt:  s.CreatedAt,
+		CreatedAtI: s.CreatedAtI,
+		Type:       s.Type,
+		Author:     s.Author,
+		Title:      s.Title,
+		URL:        s.URL,
+		Points:     s.Points,
+		Children:   s.Children,
+		Tags:       s.Tags,
+	}
+	if s.Text != nil {
+		normalized.Text = *s.Text
+		normalized.HasText = true
+	}
+	if s.NumComments != nil {
+		normalized.NumComments = *s.NumComments
+		normalized.HasComments = true
+	}
+	if s.ParentID != nil {
+		normalized.ParentID = *s.ParentID
+	}
+	if s.StoryID != nil {
+		normalized.StoryID = *s.StoryID
+	}
+	if s.StoryTitle != nil {
+		normalized.StoryTitle = *s.StoryTitle
+	}
+	if s.StoryURL != nil {
+		normalized.StoryURL = *s.StoryURL
+	}
+	if s.RelevancyScore != nil {
+		normalized.RelevancyScore = *s.RelevancyScore
+		normalized.HasRelevancyScore = true
+	}
+	return normalized
+}
@@ -0,0 +1,68 @@
+package hackernews_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestNormalized(t *testing.T) {
+	is := is.New(t)
+	text := "Hello <i>HN</i>"
+	comments := 0
+	parentID := 7
+	story := &hackernews.Story{
+		ID:          1,
+		Title:       "Ask HN: Hello",
+		Author:      "alice",
+		Text:        &text,
+		NumComments: &comments,
+		ParentID:    &parentID,
+	}
+	normalized := story.Normalized()
+	is.Equal(normalized.ID, 1)
+	is.Equal(normalized.Title, "Ask HN: Hello")
+	is.Equal(normalized.Text, text)
+	is.True(normalized.HasText)
+	is.Equal(normalized.NumComments, 0)
+	is.True(normalized.HasComments) // present, even though it's zero
+	is.Equal(normalized.ParentID, 7)
+	is.Equal(normalized.StoryID, 0)
+}
+
+func TestNormalizedNil(t *testing.T) {
+	is := is.New(t)
+	story := &hackernews.Story{ID: 2, Title: "Link", URL: "https://example.com"}
+	normalized := story.Normalized()
+	is.Equal(normalized.URL, "https://example.com")
+	is.Equal(normalized.Text, "")
+	is.True(!normalized.HasText)
+	is.Equal(normalized.NumComments, 0)
+	is.True(!normalized.HasComments)
+	is.Equal(story.Text, nil) // original is untouched
+	is.Equal(normalized.StoryTitle, "")
+	is.Equal(normalized.RelevancyScore, 0)
+	is.True(!normalized.HasRelevancyScore)
+}
+
+func TestNormalizedCommentHit(t *testing.T) {
+	is := is.New(t)
+	storyID, storyTitle, storyURL, score := 1, "Ask HN: Hello", "https://example.com", 0
+	comment := &hackernews.Story{
+		ID:             3,
+		Type:           "comment",
+		StoryID:        &storyID,
+		StoryTitle:     &storyTitle,
+		StoryURL:       &storyURL,
+		RelevancyScore: &score,
+		Tags:           []string{"comment", "author_bob", "story_1"},
+	}
+	normalized := comment.Normalized()
+	is.Equal(normalized.StoryID, 1)
+	is.Equal(normalized.StoryTitle, "Ask HN: Hello") // the story context is kept
+	is.Equal(normalized.StoryURL, "https://example.com")
+	is.Equal(normalized.Tags, []string{"comment", "author_bob", "story_1"})
+	is.Equal(normalized.RelevancyScore, 0)
+	is.True(normalized.HasRelevancyScore) // present, even though it's zero
+}
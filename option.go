@@ -0,0 +1,257 @@
+package hackernews
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures the Client
+type Option func(*Client)
+
+// WithHTTPClient sends requests with your own HTTP client instead of
+// http.DefaultClient. A nil client is ignored.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client != nil {
+			c.Client = client
+		}
+	}
+}
+
+// WithBaseURL points the client at a different API host, like a mirror or a
+// local test server. Trailing slashes are trimmed. An empty URL is ignored, so
+// it keeps defaulting to https://hn.algolia.com/api/v1.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL = strings.TrimRight(baseURL, "/"); baseURL != "" {
+			c.baseURL = baseURL
+		}
+	}
+}
+
+// WithFirebaseBaseURL points the Firebase methods, like TopStories, at a
+// different host. Trailing slashes are trimmed. It defaults to
+// https://hacker-news.firebaseio.com/v0.
+func WithFirebaseBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL = strings.TrimRight(baseURL, "/"); baseURL != "" {
+			c.firebaseURL = baseURL
+		}
+	}
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request. It
+// defaults to hackernews-go/<version>. An empty string sends Go's default.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeouts sets the default timeouts for item lookups (Find, GetUser, etc.)
+// and searches (Search, SearchRecent, etc.). The timeouts only apply when the
+// context passed in doesn't already have a deadline. A zero duration means no
+// default timeout.
+func WithTimeouts(find, search time.Duration) Option {
+	return func(c *Client) {
+		c.findTimeout = find
+		c.searchTimeout = search
+	}
+}
+
+// WithTimeout sets the same default timeout for every request, so a hung
+// endpoint can't block forever. A deadline on the context passed in takes
+// precedence, whether it's shorter or longer. There's no timeout by default.
+func WithTimeout(timeout time.Duration) Option {
+	return WithTimeouts(timeout, timeout)
+}
+
+// WithRetry retries requests that fail with a 5xx status, a 429 or a network
+// error, up to maxAttempts in total. The delay starts at baseDelay and doubles
+// after each attempt, with some jitter, unless the response has a Retry-After
+// header. Retries stop early when the context is done or its deadline would
+// pass before the next attempt. Other 4xx responses are never retried. Once
+// retries are enabled, failed requests return a *RetryError with the number of
+// attempts. Requests that succeed don't report how many attempts they took,
+// but WithLogger sees every attempt.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		c.retryAttempts = maxAttempts
+		c.retryDelay = baseDelay
+	}
+}
+
+// maxResultsPerPage is the most results Algolia returns per page
+const maxResultsPerPage = 1000
+
+// WithDefaultResultsPerPage sets the number of results per page for searches
+// that leave ResultsPerPage at zero, including the convenience methods like
+// FrontPage. It's clamped to Algolia's maximum of 1000.
+func WithDefaultResultsPerPage(n int) Option {
+	return func(c *Client) {
+		if n > maxResultsPerPage {
+			n = maxResultsPerPage
+		}
+		c.resultsPerPage = n
+	}
+}
+
+// WithDecodeChildrenText converts the HTML in each comment's Text to plain text
+// in the trees returned by Find, Comments, FindItem and the other methods that
+// return comment trees. Comments are left as raw HTML by default.
+func WithDecodeChildrenText(decode bool) Option {
+	return func(c *Client) {
+		c.decodeChildrenText = decode
+	}
+}
+
+// WithCommentOrder sets the order that Find, Comments, FindItem and the other
+// methods that return comment trees sort comments in, at every level of the
+// tree. Comments are sorted OldestFirst by default.
+func WithCommentOrder(order CommentOrder) Option {
+	return func(c *Client) {
+		c.commentOrder = order
+	}
+}
+
+// WithDeletedComments keeps removed and dead comments in the trees returned by
+// Find, Comments, FindItem and the other methods that return comment trees,
+// along with their replies, and marks them with Children.Deleted. By
+// default they're dropped, and their replies with them.
+func WithDeletedComments(keep bool) Option {
+	return func(c *Client) {
+		c.keepDeleted = keep
+	}
+}
+
+// WithReferer sets the Referer header on every request, for deployments that
+// need to identify themselves.
+func WithReferer(referer string) Option {
+	return func(c *Client) {
+		c.referer = referer
+	}
+}
+
+// WithResponseTransform calls transform with the raw body of every successful
+// response before it's decoded, so responses can be patched or redacted in one
+// place. The transform sees the body as it arrived over the network.
+//
+// With WithCache or WithETags, the cache keeps the body from before the
+// transform, and the transform runs again each time a cached body is used,
+// including after a 304 Not Modified. It should be cheap and give the same
+// result for the same body.
+func WithResponseTransform(transform func(body []byte) []byte) Option {
+	return func(c *Client) {
+		c.transform = transform
+	}
+}
+
+// WithPopulateStories controls whether Search and SearchRecent convert the hits
+// into SearchResponse.Stories. It's enabled by default. Disabling it saves the
+// conversion for callers that only use Hits. Methods built on top of search,
+// like FrontPage and SearchAll, always convert the hits they need.
+func WithPopulateStories(populate bool) Option {
+	return func(c *Client) {
+		c.skipStories = !populate
+	}
+}
+
+// WithSoftBudget limits how long methods that make many requests, like SearchN
+// and FindMany, keep going. Once the budget is spent, no new requests are
+// started and the results so far are returned with ErrBudgetExceeded. Unlike a
+// context deadline, requests that are already in flight are allowed to finish.
+func WithSoftBudget(budget time.Duration) Option {
+	return func(c *Client) {
+		c.softBudget = budget
+	}
+}
+
+// budgetExceeded returns a function that reports whether the soft budget that
+// started now has been spent
+func (c *Client) budgetExceeded() func() bool {
+	if c.softBudget <= 0 {
+		return func() bool { return false }
+	}
+	deadline := time.Now().Add(c.softBudget)
+	return func() bool {
+		return !time.Now().Before(deadline)
+	}
+}
+
+// WithConcurrency sets how many requests the batch methods, like FindMany and
+// CommentCounts, make at once. It defaults to 8.
+func WithConcurrency(n int) Option {
+	return func(c *Client) {
+		c.concurrency = n
+	}
+}
+
+// WithCache keeps successful responses in memory for the ttl, so repeated
+// calls to Find, Search and the other methods with the same arguments don't
+// hit the network. Each call still decodes its own copy of the response. Use
+// WithoutCache on the context to skip the cache for a single call.
+func WithCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		if ttl > 0 {
+			c.cache = newCache(ttl)
+		}
+	}
+}
+
+// WithETags remembers the ETag of each response and sends it back with
+// If-None-Match the next time the same URL is requested. When the server
+// responds with 304 Not Modified, the remembered body is used instead. With
+// WithCache, only expired entries are revalidated. It's a no-op for servers
+// that don't send ETags.
+func WithETags() Option {
+	return func(c *Client) {
+		c.etags = true
+	}
+}
+
+// WithLogger calls logger after every HTTP request the client makes, including
+// retries, with how long it took. The response is nil when the request failed
+// outright. Otherwise its body has already been read in full, and reading it
+// again in the logger is safe. Responses served from the cache aren't logged.
+func WithLogger(logger func(req *http.Request, res *http.Response, err error, duration time.Duration)) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithByteBudget limits the total number of response bytes the client reads.
+// The request that goes over the budget and every request after it return
+// ErrByteBudgetExceeded.
+func WithByteBudget(budget int64) Option {
+	return func(c *Client) {
+		c.byteBudget = budget
+	}
+}
+
+// WithAlgoliaCredentials sends the X-Algolia-Application-Id and
+// X-Algolia-API-Key headers with every Algolia request. Requests still go to
+// the HN API at hn.algolia.com (or the URL from WithBaseURL), since that's the
+// only place the HN search index and item endpoints are served.
+func WithAlgoliaCredentials(appID, apiKey string) Option {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		c.headers.Set("X-Algolia-Application-Id", appID)
+		c.headers.Set("X-Algolia-API-Key", apiKey)
+	}
+}
+
+// withDefaultTimeout derives a context with the timeout when ctx doesn't have a
+// deadline of its own
+func withDefaultTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,448 @@
+package hackernews_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+// slowHandler waits before responding with an empty result
+func slowHandler(delay time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		fmt.Fprint(w, `{"id":1,"hits":[]}`)
+	})
+}
+
+func TestWithTimeoutsFind(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, slowHandler(100*time.Millisecond), hackernews.WithTimeouts(10*time.Millisecond, time.Minute))
+	_, err := hn.Find(ctx, 1)
+	is.True(errors.Is(err, context.DeadlineExceeded)) // find timeout applies
+	_, err = hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err) // search timeout is longer
+	_, err = hn.SearchRecent(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err) // search timeout is longer
+}
+
+func TestWithTimeoutsSearch(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, slowHandler(100*time.Millisecond), hackernews.WithTimeouts(time.Minute, 10*time.Millisecond))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.True(errors.Is(err, context.DeadlineExceeded)) // search timeout applies
+	_, err = hn.SearchRecent(ctx, &hackernews.SearchRequest{})
+	is.True(errors.Is(err, context.DeadlineExceeded)) // search timeout applies
+	_, err = hn.Find(ctx, 1)
+	is.NoErr(err) // find timeout is longer
+}
+
+func TestWithTimeout(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, slowHandler(100*time.Millisecond), hackernews.WithTimeout(10*time.Millisecond))
+	_, err := hn.Find(ctx, 1)
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	_, err = hn.Search(ctx, &hackernews.SearchRequest{})
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	_, err = hn.SearchRecent(ctx, &hackernews.SearchRequest{})
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	// A longer caller deadline takes precedence
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	_, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+}
+
+func TestWithTimeoutsCallerDeadline(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	hn := fakeClient(t, slowHandler(50*time.Millisecond), hackernews.WithTimeouts(10*time.Millisecond, 10*time.Millisecond))
+	_, err := hn.Find(ctx, 1)
+	is.NoErr(err) // the caller's deadline wins
+}
+
+func TestWithDefaultResultsPerPage(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var perPage []string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage = append(perPage, r.URL.Query().Get("hitsPerPage"))
+		fmt.Fprint(w, `{"hits":[]}`)
+	}), hackernews.WithDefaultResultsPerPage(50))
+	search := &hackernews.SearchRequest{Query: "go"}
+	_, err := hn.Search(ctx, search)
+	is.NoErr(err)
+	is.Equal(search.ResultsPerPage, 0) // request is untouched
+	_, err = hn.SearchRecent(ctx, &hackernews.SearchRequest{Query: "go", ResultsPerPage: 5})
+	is.NoErr(err)
+	_, err = hn.FrontPage(ctx)
+	is.NoErr(err)
+	is.Equal(perPage, []string{"50", "5", "50"}) // request's own value wins
+}
+
+func TestWithDefaultResultsPerPageClamped(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var perPage string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage = r.URL.Query().Get("hitsPerPage")
+		fmt.Fprint(w, `{"hits":[]}`)
+	}), hackernews.WithDefaultResultsPerPage(5000))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	is.Equal(perPage, "1000")
+}
+
+func TestResultsPerPageDefault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var perPage []string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage = append(perPage, r.URL.Query().Get("hitsPerPage"))
+		fmt.Fprint(w, `{"hits":[]}`)
+	}))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	_, err = hn.Newest(ctx)
+	is.NoErr(err)
+	is.Equal(perPage, []string{"", "34"}) // unchanged without the option
+}
+
+func TestWithDecodeChildrenText(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveFile(t, "testdata/item.json"), hackernews.WithDecodeChildrenText(true))
+	story, err := hn.Find(ctx, 100)
+	is.NoErr(err)
+	is.Equal(*story.Children[0].Text, "I use Emacs. See https://www.gnu.org/software/emacs/")
+	is.Equal(*story.Children[1].Children[0].Text, "Emacs > Vim") // nested comments too
+}
+
+func TestWithoutDecodeChildrenText(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveFile(t, "testdata/item.json"))
+	story, err := hn.Find(ctx, 100)
+	is.NoErr(err)
+	is.Equal(*story.Children[1].Children[0].Text, "Emacs &gt; Vim") // raw by default
+}
+
+func TestWithReferer(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var referers []string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		referers = append(referers, r.Header.Get("Referer"))
+		fmt.Fprint(w, `{"id":1,"hits":[]}`)
+	}), hackernews.WithReferer("https://example.com/reader"))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	_, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(referers, []string{"https://example.com/reader", "https://example.com/reader"})
+}
+
+func TestWithoutReferer(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var referer []string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		referer = r.Header.Values("Referer")
+		fmt.Fprint(w, `{"hits":[]}`)
+	}))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	is.Equal(len(referer), 0) // no header
+}
+
+func TestWithResponseTransform(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveFile(t, "testdata/item.json"), hackernews.WithResponseTransform(func(body []byte) []byte {
+		return bytes.Replace(body, []byte("favorite editor"), []byte("favourite editor"), 1)
+	}))
+	story, err := hn.Find(ctx, 100)
+	is.NoErr(err)
+	is.Equal(story.Title, "Ask HN: What's your favourite editor?")
+}
+
+func TestWithPopulateStories(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"hits":[{"objectID":"1"},{"objectID":"2"}]}`)
+	})
+	hn := fakeClient(t, handler, hackernews.WithPopulateStories(false))
+	result, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	is.Equal(len(result.Hits), 2)
+	is.Equal(len(result.Stories), 0) // not populated
+	result, err = hn.SearchRecent(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	is.Equal(len(result.Hits), 2)
+	is.Equal(len(result.Stories), 0) // not populated
+	hn = fakeClient(t, handler)
+	result, err = hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	is.Equal(len(result.Stories), 2) // populated by default
+}
+
+func TestWithPopulateStoriesHelpers(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"hits":[{"objectID":"1","num_comments":0},{"objectID":"2"}],"page":0,"nbPages":1}`)
+	})
+	hn := fakeClient(t, handler, hackernews.WithPopulateStories(false))
+	result, err := hn.NoComments(ctx, "story")
+	is.NoErr(err)
+	is.Equal(len(result.Hits), 2) // helpers always convert the hits
+	is.Equal(len(result.Stories), 2)
+	stories, err := hn.SearchAll(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	is.Equal(len(stories), 2)
+}
+
+func TestWithSoftBudgetSearchN(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	pages := pagedHandler(t, 100, 5)
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		pages.ServeHTTP(w, r)
+	}), hackernews.WithSoftBudget(50*time.Millisecond))
+	stories, err := hn.SearchN(ctx, &hackernews.SearchRequest{}, 500)
+	is.True(errors.Is(err, hackernews.ErrBudgetExceeded))
+	is.True(len(stories) >= 5)  // returns what it has
+	is.True(len(stories) < 500) // stopped early
+}
+
+func TestWithSoftBudgetFindMany(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	slow := map[string]time.Duration{}
+	ids := make([]int, 40)
+	for i := range ids {
+		ids[i] = i + 1
+		slow[fmt.Sprint(i+1)] = 30 * time.Millisecond
+	}
+	hn := fakeClient(t, itemHandler(slow, nil), hackernews.WithSoftBudget(10*time.Millisecond))
+	stories, err := hn.FindMany(ctx, ids)
+	is.True(errors.Is(err, hackernews.ErrBudgetExceeded))
+	is.Equal(len(stories), len(ids))
+	found := 0
+	for _, story := range stories {
+		if story != nil {
+			found++
+		}
+	}
+	is.True(found > 0)        // in-flight lookups finished
+	is.True(found < len(ids)) // the rest were skipped
+}
+
+// roundTripFunc lets a function stand in for a transport
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func TestWithAlgoliaCredentials(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var req *http.Request
+	hn := hackernews.New(hackernews.WithAlgoliaCredentials("MYAPP", "secret"))
+	hn.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		req = r
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"hits":[]}`)),
+		}, nil
+	})}
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{Query: "go"})
+	is.NoErr(err)
+	is.Equal(req.Header.Get("X-Algolia-Application-Id"), "MYAPP")
+	is.Equal(req.Header.Get("X-Algolia-API-Key"), "secret")
+	is.Equal(req.URL.Scheme, "https")
+	is.Equal(req.URL.Host, "hn.algolia.com") // the HN API is only served here
+	is.Equal(req.URL.Path, "/api/v1/search")
+}
+
+func TestWithAlgoliaCredentialsBaseURL(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		fmt.Fprint(w, `{"hits":[]}`)
+	}))
+	defer server.Close()
+	orders := [][]hackernews.Option{
+		{hackernews.WithBaseURL(server.URL), hackernews.WithAlgoliaCredentials("MYAPP", "secret")},
+		{hackernews.WithAlgoliaCredentials("MYAPP", "secret"), hackernews.WithBaseURL(server.URL)},
+	}
+	for _, options := range orders {
+		header = nil
+		_, err := hackernews.New(options...).Search(ctx, &hackernews.SearchRequest{Query: "go"})
+		is.NoErr(err) // the base URL is kept whatever the order
+		is.Equal(header.Get("X-Algolia-Application-Id"), "MYAPP")
+	}
+}
+
+func TestWithoutAlgoliaCredentials(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var header http.Header
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		fmt.Fprint(w, `{"hits":[]}`)
+	}))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{Query: "go"})
+	is.NoErr(err)
+	is.Equal(header.Get("X-Algolia-Application-Id"), "") // keyless by default
+	is.Equal(header.Get("X-Algolia-API-Key"), "")
+}
+
+func TestWithHTTPClientAndBaseURL(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var paths, agents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		agents = append(agents, r.Header.Get("User-Agent"))
+		fmt.Fprint(w, `{"id":1,"hits":[]}`)
+	}))
+	defer server.Close()
+	client := &http.Client{}
+	hn := hackernews.New(
+		hackernews.WithHTTPClient(client),
+		hackernews.WithBaseURL(server.URL+"/api/v1"),
+		hackernews.WithUserAgent("reader/1.0"),
+	)
+	is.True(hn.Client == client)
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	_, err = hn.SearchRecent(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	_, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(paths, []string{"/api/v1/search", "/api/v1/search_by_date", "/api/v1/items/1"})
+	is.Equal(agents, []string{"reader/1.0", "reader/1.0", "reader/1.0"})
+}
+
+func TestWithHTTPClientNil(t *testing.T) {
+	is := is.New(t)
+	hn := hackernews.New(hackernews.WithHTTPClient(nil))
+	is.True(hn.Client == http.DefaultClient)
+}
+
+func TestWithBaseURLTrailingSlash(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		fmt.Fprint(w, `{"id":1}`)
+	}))
+	defer server.Close()
+	hn := hackernews.New(hackernews.WithBaseURL(server.URL + "/api/v1//"))
+	_, err := hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(path, "/api/v1/items/1")
+}
+
+func TestDefaultUserAgent(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var agents []string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents = append(agents, r.Header.Get("User-Agent"))
+		fmt.Fprint(w, `{"id":1,"hits":[]}`)
+	}))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	_, err = hn.SearchRecent(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	_, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+	is.Equal(len(agents), 3)
+	changelog, err := os.ReadFile("Changelog.md")
+	is.NoErr(err)
+	for _, agent := range agents {
+		version := strings.TrimPrefix(agent, "hackernews-go/")
+		is.True(version != agent)
+		is.True(strings.Contains(string(changelog), "# "+version+" / ")) // a release from the changelog
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	type entry struct {
+		path   string
+		status int
+		body   string
+		err    error
+	}
+	var entries []entry
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/items/2" {
+			http.Error(w, "missing", http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id":1,"hits":[]}`)
+	}), hackernews.WithLogger(func(req *http.Request, res *http.Response, err error, duration time.Duration) {
+		body, readErr := io.ReadAll(res.Body)
+		is.NoErr(readErr)
+		is.True(duration > 0)
+		entries = append(entries, entry{req.URL.Path, res.StatusCode, string(body), err})
+	}))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	_, err = hn.SearchRecent(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	_, err = hn.Find(ctx, 1)
+	is.NoErr(err)
+	_, err = hn.Find(ctx, 2)
+	is.True(err != nil)
+	is.Equal(entries, []entry{
+		{"/api/v1/search", 200, `{"id":1,"hits":[]}`, nil},
+		{"/api/v1/search_by_date", 200, `{"id":1,"hits":[]}`, nil},
+		{"/api/v1/items/1", 200, `{"id":1,"hits":[]}`, nil},
+		{"/api/v1/items/2", 404, "missing\n", nil},
+	})
+}
+
+func TestWithLoggerNetworkError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	calls := 0
+	hn := hackernews.New(hackernews.WithBaseURL(server.URL), hackernews.WithLogger(func(req *http.Request, res *http.Response, err error, duration time.Duration) {
+		calls++
+		is.Equal(res, nil)
+		is.True(err != nil)
+	}))
+	_, err := hn.Find(ctx, 1)
+	is.True(err != nil)
+	is.Equal(calls, 1)
+}
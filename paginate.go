@@ -0,0 +1,124 @@
+package hackernews
+
+import (
+	"context"
+	"errors"
+)
+
+// SearchN pages through the search results until it has n stories or the
+// results are exhausted. At most n stories are returned. When the soft budget
+// runs out, the stories so far are returned with ErrBudgetExceeded.
+func (c *Client) SearchN(ctx context.Context, req *SearchRequest, n int) ([]*Story, error) {
+	stories := []*Story{}
+	if n <= 0 {
+		return stories, nil
+	}
+	err := c.eachPage(ctx, c.searchStories, req, func(result *SearchResponse) (bool, error) {
+		for _, story := range result.Stories {
+			stories = append(stories, story)
+			if len(stories) == n {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if errors.Is(err, ErrBudgetExceeded) {
+		return stories, err
+	} else if err != nil {
+		return nil, err
+	}
+	return stories, nil
+}
+
+// SearchAll pages through every page of the search results, starting from the
+// first, and returns the stories in order. Stories that show up on more than
+// one page (because the results shifted while paging) are only included once.
+// Set MaxPages on the request to stop early. When the soft budget runs out,
+// the stories so far are returned with ErrBudgetExceeded.
+func (c *Client) SearchAll(ctx context.Context, req *SearchRequest) ([]*Story, error) {
+	stories, err := c.uniqueStories(ctx, c.searchStories, req, map[int]bool{})
+	if errors.Is(err, ErrBudgetExceeded) {
+		return stories, err
+	} else if err != nil {
+		return nil, err
+	}
+	return stories, nil
+}
+
+// uniqueStories pages through the results of the search from the first page,
+// up to req.MaxPages, skipping the stories in seen and adding the new ones to
+// it. The stories so far are returned alongside any error.
+func (c *Client) uniqueStories(ctx context.Context, search searchFunc, req *SearchRequest, seen map[int]bool) ([]*Story, error) {
+	first := *req
+	first.Page = 1
+	stories := []*Story{}
+	pages := 0
+	err := c.eachPage(ctx, search, &first, func(result *SearchResponse) (bool, error) {
+		for _, story := range result.Stories {
+			if seen[story.ID] {
+				continue
+			}
+			seen[story.ID] = true
+			stories = append(stories, story)
+		}
+		pages++
+		return req.MaxPages <= 0 || pages < req.MaxPages, nil
+	})
+	return stories, err
+}
+
+// HasNextPage reports whether there are more pages of results after this one
+func (r *SearchResponse) HasNextPage() bool {
+	return r.Page < r.NumPages
+}
+
+// HasPrevPage reports whether there are pages of results before this one
+func (r *SearchResponse) HasPrevPage() bool {
+	return r.Page > 1
+}
+
+// NextRequest returns a copy of the request that made this response, asking
+// for the following page. The original request is left untouched.
+func (r *SearchResponse) NextRequest(prev *SearchRequest) *SearchRequest {
+	next := *prev
+	next.Page = r.Page + 1
+	return &next
+}
+
+// searchFunc is how eachPage gets each page, usually searchStories or
+// searchRecentStories
+type searchFunc func(ctx context.Context, search *SearchRequest) (*SearchResponse, error)
+
+// eachPage calls fn with each page of search results, starting from the
+// request's page. It stops once fn returns false or there are no more pages.
+// The original request is left untouched. ErrBudgetExceeded is returned if the
+// soft budget runs out before then.
+func (c *Client) eachPage(ctx context.Context, search searchFunc, req *SearchRequest, fn func(result *SearchResponse) (bool, error)) error {
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	exceeded := c.budgetExceeded()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		next := *req
+		next.Page = page
+		result, err := search(ctx, &next)
+		if err != nil {
+			return err
+		}
+		more, err := fn(result)
+		if err != nil {
+			return err
+		}
+		if !more || len(result.Hits) == 0 || result.Page >= result.NumPages {
+			return nil
+		}
+		if exceeded() {
+			return ErrBudgetExceeded
+		}
+		page++
+	}
+}
@@ -0,0 +1,189 @@
+package hackernews_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+// pagedHandler serves pages of perPage stories with sequential IDs
+func pagedHandler(t testing.TB, pages, perPage int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		hits := []map[string]interface{}{}
+		if page < pages {
+			for i := 0; i < perPage; i++ {
+				id := page*perPage + i + 1
+				hits = append(hits, map[string]interface{}{
+					"objectID": strconv.Itoa(id),
+					"title":    "Story " + strconv.Itoa(id),
+				})
+			}
+		}
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"hits":        hits,
+			"page":        page,
+			"nbPages":     pages,
+			"hitsPerPage": perPage,
+			"nbHits":      pages * perPage,
+		}); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestSearchN(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, pagedHandler(t, 3, 3))
+	req := &hackernews.SearchRequest{Tags: "story"}
+	stories, err := hn.SearchN(ctx, req, 5)
+	is.NoErr(err)
+	is.Equal(len(stories), 5) // exactly n when more exist
+	for i, story := range stories {
+		is.Equal(story.ID, i+1)
+	}
+	is.Equal(req.Page, 0) // request is not mutated
+}
+
+func TestSearchNExhausted(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, pagedHandler(t, 3, 3))
+	stories, err := hn.SearchN(ctx, &hackernews.SearchRequest{Tags: "story"}, 20)
+	is.NoErr(err)
+	is.Equal(len(stories), 9) // every result when exhausted
+}
+
+func TestSearchNCancelled(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hn := fakeClient(t, pagedHandler(t, 3, 3))
+	_, err := hn.SearchN(ctx, &hackernews.SearchRequest{Tags: "story"}, 5)
+	is.True(errors.Is(err, context.Canceled))
+}
+
+func TestSearchAll(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, pagedHandler(t, 3, 3))
+	req := &hackernews.SearchRequest{Tags: "story", Page: 2}
+	stories, err := hn.SearchAll(ctx, req)
+	is.NoErr(err)
+	is.Equal(len(stories), 9) // starts from the first page
+	for i, story := range stories {
+		is.Equal(story.ID, i+1)
+	}
+	is.Equal(req.Page, 2) // request is not mutated
+}
+
+func TestSearchAllMaxPages(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	requests := 0
+	handler := pagedHandler(t, 10, 3)
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		handler.ServeHTTP(w, r)
+	}))
+	stories, err := hn.SearchAll(ctx, &hackernews.SearchRequest{MaxPages: 2})
+	is.NoErr(err)
+	is.Equal(len(stories), 6)
+	is.Equal(requests, 2)
+}
+
+func TestSearchAllDedup(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	// The second page overlaps the first, like when a new story pushes the
+	// results down while paging
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "", "0":
+			fmt.Fprint(w, `{"hits":[{"objectID":"1"},{"objectID":"2"},{"objectID":"3"}],"page":0,"nbPages":2}`)
+		default:
+			fmt.Fprint(w, `{"hits":[{"objectID":"3"},{"objectID":"4"}],"page":1,"nbPages":2}`)
+		}
+	}))
+	stories, err := hn.SearchAll(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	ids := []int{}
+	for _, story := range stories {
+		ids = append(ids, story.ID)
+	}
+	is.Equal(ids, []int{1, 2, 3, 4})
+}
+
+func TestSearchAllCanceled(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hn := fakeClient(t, pagedHandler(t, 3, 3))
+	_, err := hn.SearchAll(ctx, &hackernews.SearchRequest{})
+	is.True(errors.Is(err, context.Canceled))
+}
+
+func TestSearchResponsePages(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, pagedHandler(t, 3, 2))
+	req := &hackernews.SearchRequest{Tags: "story"}
+	var ids []int
+	pages := 0
+	for {
+		result, err := hn.Search(ctx, req)
+		is.NoErr(err)
+		pages++
+		is.Equal(result.HasPrevPage(), pages > 1)
+		for _, story := range result.Stories {
+			ids = append(ids, story.ID)
+		}
+		if !result.HasNextPage() {
+			break
+		}
+		next := result.NextRequest(req)
+		is.Equal(next.Tags, "story")
+		is.Equal(next.Page, result.Page+1)
+		req = next
+	}
+	is.Equal(pages, 3)
+	is.Equal(ids, []int{1, 2, 3, 4, 5, 6})
+}
+
+func TestSearchAndSearchRecentPaging(t *testing.T) {
+	ctx := context.Background()
+	hn := fakeClient(t, pagedHandler(t, 3, 2))
+	methods := map[string]func(context.Context, *hackernews.SearchRequest) (*hackernews.SearchResponse, error){
+		"Search":       hn.Search,
+		"SearchRecent": hn.SearchRecent,
+	}
+	for name, search := range methods {
+		search := search
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			for _, test := range []struct {
+				page, resultPage, firstID int
+			}{
+				{0, 1, 1},
+				{1, 1, 1},
+				{2, 2, 3},
+				{3, 3, 5},
+			} {
+				req := &hackernews.SearchRequest{Page: test.page}
+				result, err := search(ctx, req)
+				is.NoErr(err)
+				is.Equal(req.Page, test.page) // request is not mutated
+				is.Equal(result.Page, test.resultPage)
+				is.Equal(result.Stories[0].ID, test.firstID)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package hackernews
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PriorDiscussions finds older submissions of the same URL that were created
+// before the given time. Stories are sorted by points, highest first.
+// Tracking parameters, the scheme and "www." are ignored when comparing URLs.
+func (c *Client) PriorDiscussions(ctx context.Context, rawURL string, before time.Time) ([]*Story, error) {
+	normalized := normalizeURL(rawURL)
+	if normalized == "" {
+		return nil, errors.New("hackernews: invalid url " + strconv.Quote(rawURL))
+	}
+	result, err := c.searchStories(ctx, &SearchRequest{
+		Query:          normalized,
+		Tags:           "story",
+		CreatedAt:      "<" + strconv.FormatInt(before.Unix(), 10),
+		ResultsPerPage: 100,
+	})
+	if err != nil {
+		return nil, err
+	}
+	stories := []*Story{}
+	for _, story := range result.Stories {
+		if !story.createdAt().Before(before) || normalizeURL(story.URL) != normalized {
+			continue
+		}
+		stories = append(stories, story)
+	}
+	sort.SliceStable(stories, func(i, j int) bool {
+		return stories[i].Points > stories[j].Points
+	})
+	return stories, nil
+}
+
+// trackingParams are query parameters that don't change the linked content
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"ref":     true,
+	"ref_src": true,
+}
+
+// normalizeURL reduces a URL to its host, path and meaningful query so that
+// different submissions of the same link compare equal. It returns an empty
+// string for URLs that can't be parsed.
+func normalizeURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	query := u.Query()
+	for key := range query {
+		if trackingParams[strings.ToLower(key)] || strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	normalized := host + strings.TrimSuffix(u.EscapedPath(), "/")
+	if len(query) > 0 {
+		normalized += "?" + query.Encode()
+	}
+	return normalized
+}
@@ -0,0 +1,47 @@
+package hackernews_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestPriorDiscussions(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	before := time.Unix(1700000000, 0)
+	var filters string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filters = r.URL.Query().Get("numericFilters")
+		// The fake server ignores the filter to make sure the client double-checks
+		fmt.Fprint(w, `{"hits":[
+			{"objectID":"1","url":"https://example.com/post","points":10,"created_at_i":1600000000},
+			{"objectID":"2","url":"http://www.example.com/post/?utm_source=hn","points":50,"created_at_i":1650000000},
+			{"objectID":"3","url":"https://example.com/post","points":99,"created_at_i":1700000500},
+			{"objectID":"4","url":"https://example.com/other","points":70,"created_at_i":1600000000}
+		]}`)
+	}))
+	stories, err := hn.PriorDiscussions(ctx, "https://www.example.com/post?utm_campaign=x", before)
+	is.NoErr(err)
+	is.Equal(filters, "created_at_i<1700000000")
+	is.Equal(len(stories), 2)  // only pre-before stories with the same url
+	is.Equal(stories[0].ID, 2) // highest points first
+	is.Equal(stories[1].ID, 1)
+	for _, story := range stories {
+		is.True(time.Unix(int64(story.CreatedAtI), 0).Before(before)) // created before
+	}
+}
+
+func TestPriorDiscussionsInvalidURL(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.NotFoundHandler())
+	_, err := hn.PriorDiscussions(ctx, "not a url", time.Now())
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "invalid url"))
+}
@@ -0,0 +1,54 @@
+package hackernews
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RankingInfo explains how a hit was ranked. It's only returned when the search
+// request sets RankingInfo.
+type RankingInfo struct {
+	NumTypos          int `json:"nbTypos"`
+	FirstMatchedWord  int `json:"firstMatchedWord"`
+	ProximityDistance int `json:"proximityDistance"`
+	UserScore         int `json:"userScore"`
+	NumExactWords     int `json:"nbExactWords"`
+	Words             int `json:"words"`
+	Filters           int `json:"filters"`
+}
+
+// ExplainRanking describes in a sentence why the hit ranked where it did. It
+// returns an empty string unless the search request asked for RankingInfo.
+func (h *Hit) ExplainRanking() string {
+	info := h.RankingInfo
+	if info == nil {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Matched %s", plural(info.Words, "word", "words"))
+	if info.Words > 0 {
+		fmt.Fprintf(&b, " (%d exactly)", info.NumExactWords)
+	}
+	switch info.NumTypos {
+	case 0:
+		b.WriteString(" with no typos")
+	default:
+		fmt.Fprintf(&b, " with %s", plural(info.NumTypos, "typo", "typos"))
+	}
+	if info.Words > 1 {
+		fmt.Fprintf(&b, ", %s apart", plural(info.ProximityDistance, "word", "words"))
+	}
+	// Algolia encodes the first matched word as attribute * 1000 + position
+	if info.Words > 0 {
+		fmt.Fprintf(&b, ", first match at word %d of attribute %d", info.FirstMatchedWord%1000, info.FirstMatchedWord/1000)
+	}
+	b.WriteString(".")
+	return b.String()
+}
+
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -0,0 +1,35 @@
+package hackernews_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestExplainRanking(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var getRankingInfo string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		getRankingInfo = r.URL.Query().Get("getRankingInfo")
+		fmt.Fprint(w, `{"hits":[
+			{"objectID":"1","_rankingInfo":{"nbTypos":0,"firstMatchedWord":0,"proximityDistance":1,"userScore":10,"nbExactWords":2,"words":2,"filters":0}},
+			{"objectID":"2","_rankingInfo":{"nbTypos":1,"firstMatchedWord":1003,"proximityDistance":0,"userScore":5,"nbExactWords":0,"words":1,"filters":0}}
+		]}`)
+	}))
+	result, err := hn.Search(ctx, &hackernews.SearchRequest{Query: "rust lang", RankingInfo: true})
+	is.NoErr(err)
+	is.Equal(getRankingInfo, "true")
+	is.Equal(result.Hits[0].ExplainRanking(), "Matched 2 words (2 exactly) with no typos, 1 word apart, first match at word 0 of attribute 0.")
+	is.Equal(result.Hits[1].ExplainRanking(), "Matched 1 word (0 exactly) with 1 typo, first match at word 3 of attribute 1.")
+}
+
+func TestExplainRankingNotRequested(t *testing.T) {
+	is := is.New(t)
+	hit := &hackernews.Hit{ID: "1"}
+	is.Equal(hit.ExplainRanking(), "")
+}
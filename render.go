@@ -0,0 +1,143 @@
+package hackernews
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ThreadRenderOptions configures how WriteThread renders a story
+type ThreadRenderOptions struct {
+	// Indent is repeated once per nesting level. Defaults to two spaces.
+	Indent string
+
+	// MaxDepth limits how many levels of comments are written. A MaxDepth of 1
+	// only writes the top-level comments. Defaults to 0, which writes them all.
+	MaxDepth int
+
+	// RawHTML writes the bodies as they come from Hacker News instead of
+	// converting them to plain text.
+	RawHTML bool
+
+	// Width wraps lines at word boundaries so they fit in Width columns,
+	// indentation included. Words that are too long are left whole. Defaults
+	// to 0, which doesn't wrap.
+	Width int
+}
+
+// treeWidth is the width that RenderTree wraps lines at
+const treeWidth = 80
+
+// minWrapWidth keeps deeply indented comments readable when wrapping
+const minWrapWidth = 20
+
+// RenderTree writes the story and its comments to w as an ASCII tree, with
+// replies indented under their parent and lines wrapped at 80 columns. Use
+// WriteThread to pick a different width.
+func (s *Story) RenderTree(w io.Writer) error {
+	return s.WriteThread(w, ThreadRenderOptions{Indent: "|   ", Width: treeWidth})
+}
+
+// WriteThread writes the story and its comment tree to w as indented text.
+func (s *Story) WriteThread(w io.Writer, opts ThreadRenderOptions) error {
+	if opts.Indent == "" {
+		opts.Indent = "  "
+	}
+	tw := &threadWriter{w: w, opts: opts}
+	tw.line("", s.Title)
+	tw.line("", storyByline(s))
+	if s.URL != "" {
+		tw.line("", s.URL)
+	}
+	if s.Text != nil {
+		tw.line("", "")
+		tw.body("", *s.Text)
+	}
+	tw.children(s.Children)
+	return tw.err
+}
+
+func storyByline(s *Story) string {
+	byline := strconv.Itoa(s.Points) + " points by " + s.Author
+	if s.NumComments != nil {
+		byline += " | " + strconv.Itoa(*s.NumComments) + " comments"
+	}
+	return byline
+}
+
+// threadWriter holds onto the first write error so rendering can carry on
+// without checking each write
+type threadWriter struct {
+	w    io.Writer
+	opts ThreadRenderOptions
+	err  error
+}
+
+func (tw *threadWriter) children(children []Children) {
+	walkChildren(children, 1, func(depth int, child *Children) error {
+		prefix := strings.Repeat(tw.opts.Indent, depth-1)
+		tw.line("", "")
+		author := "[deleted]"
+		if child.Author != nil {
+			author = *child.Author
+		}
+		tw.line(prefix, author+":")
+		if child.Text != nil {
+			tw.body(prefix, *child.Text)
+		}
+		if depth == tw.opts.MaxDepth {
+			return SkipChildren
+		}
+		return nil
+	})
+}
+
+func (tw *threadWriter) body(prefix, body string) {
+	if !tw.opts.RawHTML {
+		body = plainText(body)
+	}
+	for _, line := range strings.Split(body, "\n") {
+		tw.line(prefix, line)
+	}
+}
+
+func (tw *threadWriter) line(prefix, line string) {
+	width := 0
+	if tw.opts.Width > 0 {
+		width = tw.opts.Width - len([]rune(prefix))
+		if width < minWrapWidth {
+			width = minWrapWidth
+		}
+	}
+	for _, line := range wrap(line, width) {
+		if tw.err != nil {
+			return
+		}
+		if line == "" {
+			prefix = strings.TrimRight(prefix, " \t")
+		}
+		_, tw.err = fmt.Fprintln(tw.w, prefix+line)
+	}
+}
+
+// wrap splits the line into lines of at most width characters at word
+// boundaries. A width of 0 or less leaves the line alone.
+func wrap(line string, width int) []string {
+	if width <= 0 || len([]rune(line)) <= width {
+		return []string{line}
+	}
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(line) {
+		if current != "" && len([]rune(current))+1+len([]rune(word)) > width {
+			lines = append(lines, current)
+			current = ""
+		}
+		if current != "" {
+			current += " "
+		}
+		current += word
+	}
+	return append(lines, current)
+}
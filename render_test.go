@@ -0,0 +1,103 @@
+package hackernews_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// golden compares actual to the golden file, rewriting it with -update
+func golden(t testing.TB, path string, actual []byte) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, actual, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("%s doesn't match, got:\n%s", path, actual)
+	}
+}
+
+func TestWriteThread(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveFile(t, "testdata/item.json"))
+	story, err := hn.Find(ctx, 100)
+	is.NoErr(err)
+	buf := new(bytes.Buffer)
+	is.NoErr(story.WriteThread(buf, hackernews.ThreadRenderOptions{}))
+	golden(t, "testdata/thread.golden", buf.Bytes())
+}
+
+func TestWriteThreadMaxDepth(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveFile(t, "testdata/item.json"))
+	story, err := hn.Find(ctx, 100)
+	is.NoErr(err)
+	buf := new(bytes.Buffer)
+	is.NoErr(story.WriteThread(buf, hackernews.ThreadRenderOptions{
+		Indent:   "\t",
+		MaxDepth: 1,
+		RawHTML:  true,
+	}))
+	golden(t, "testdata/thread_depth.golden", buf.Bytes())
+}
+
+func TestRenderTree(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, serveFile(t, "testdata/item.json"))
+	story, err := hn.Find(ctx, 100)
+	is.NoErr(err)
+	buf := new(bytes.Buffer)
+	is.NoErr(story.RenderTree(buf))
+	golden(t, "testdata/tree.golden", buf.Bytes())
+}
+
+func TestWriteThreadWidth(t *testing.T) {
+	is := is.New(t)
+	author, text := "pg", "<p>This comment is long enough that it has to wrap onto a few lines, even at the top level."
+	reply := "Replies are wrapped to the same width, indentation included, but never narrower than twenty columns."
+	story := &hackernews.Story{
+		Title:  "Wrapping",
+		Author: "sama",
+		Text:   &text,
+		Children: []hackernews.Children{
+			{Author: &author, Text: &text, Children: []hackernews.Children{
+				{Author: &author, Text: &reply, Children: []hackernews.Children{
+					{Author: &author, Text: &reply},
+				}},
+			}},
+		},
+	}
+	buf := new(bytes.Buffer)
+	is.NoErr(story.WriteThread(buf, hackernews.ThreadRenderOptions{Indent: "            ", Width: 32}))
+	golden(t, "testdata/thread_width.golden", buf.Bytes())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteThreadError(t *testing.T) {
+	is := is.New(t)
+	story := &hackernews.Story{Title: "Title"}
+	err := story.WriteThread(failingWriter{}, hackernews.ThreadRenderOptions{})
+	is.True(err != nil)
+}
@@ -0,0 +1,70 @@
+package hackernews
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// retry sends the request, retrying transient failures when retries are
+// enabled
+func (c *Client) retry(ctx context.Context, req *http.Request, v interface{}) (*response, error) {
+	if c.retryAttempts <= 1 {
+		return c.do(ctx, req, v)
+	}
+	delay := c.retryDelay
+	for attempt := 1; ; attempt++ {
+		res, err := c.do(ctx, req, v)
+		if err == nil {
+			return res, nil
+		}
+		if attempt >= c.retryAttempts || !c.retryable(ctx, err) {
+			return nil, &RetryError{Attempts: attempt, Err: err}
+		}
+		wait := jitter(delay)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return nil, &RetryError{Attempts: attempt, Err: err}
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &RetryError{Attempts: attempt, Err: ctx.Err()}
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// retryable reports whether the request might succeed if it's sent again
+func (c *Client) retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrByteBudgetExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	var decodeErr *decodeError
+	if errors.As(err, &decodeErr) {
+		return false
+	}
+	// Anything else is a network error
+	return true
+}
+
+// jitter picks a random delay between half and all of d, so clients that
+// failed together don't all retry together
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
@@ -0,0 +1,175 @@
+package hackernews_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+// failingHandler responds with status to the first n requests and succeeds
+// after that
+func failingHandler(status, n int, calls *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if *calls <= n {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		fmt.Fprint(w, `{"id":1,"hits":[]}`)
+	})
+}
+
+func TestRetryInvalidJSON(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	calls := 0
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"hits":[`)
+	}), hackernews.WithRetry(3, time.Millisecond))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.True(err != nil)
+	is.Equal(calls, 1) // invalid responses aren't retried
+}
+
+func TestWithRetry(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	calls := 0
+	hn := fakeClient(t, failingHandler(http.StatusServiceUnavailable, 2, &calls), hackernews.WithRetry(3, time.Millisecond))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	is.Equal(calls, 3)
+}
+
+func TestWithRetryGivesUp(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	calls := 0
+	hn := fakeClient(t, failingHandler(http.StatusInternalServerError, 10, &calls), hackernews.WithRetry(3, time.Millisecond))
+	_, err := hn.Find(ctx, 1)
+	is.True(err != nil)
+	is.Equal(calls, 3)
+	var retryErr *hackernews.RetryError
+	is.True(errors.As(err, &retryErr))
+	is.Equal(retryErr.Attempts, 3)
+	var apiErr *hackernews.APIError
+	is.True(errors.As(err, &apiErr))
+	is.Equal(apiErr.StatusCode, http.StatusInternalServerError)
+}
+
+func TestWithRetryClientError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	calls := 0
+	hn := fakeClient(t, failingHandler(http.StatusBadRequest, 10, &calls), hackernews.WithRetry(3, time.Millisecond))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.Equal(calls, 1) // 4xx isn't retried
+	var retryErr *hackernews.RetryError
+	is.True(errors.As(err, &retryErr))
+	is.Equal(retryErr.Attempts, 1)
+}
+
+func TestWithRetryNetworkError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	hn := hackernews.New(hackernews.WithBaseURL(server.URL), hackernews.WithRetry(2, time.Millisecond))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	var retryErr *hackernews.RetryError
+	is.True(errors.As(err, &retryErr))
+	is.Equal(retryErr.Attempts, 2)
+}
+
+func TestWithRetryDeadline(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	calls := 0
+	hn := fakeClient(t, failingHandler(http.StatusBadGateway, 10, &calls), hackernews.WithRetry(5, time.Minute))
+	start := time.Now()
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.True(time.Since(start) < time.Second) // didn't wait past the deadline
+	is.Equal(calls, 1)
+	var apiErr *hackernews.APIError
+	is.True(errors.As(err, &apiErr))
+}
+
+func TestWithoutRetry(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	calls := 0
+	hn := fakeClient(t, failingHandler(http.StatusServiceUnavailable, 10, &calls))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.Equal(calls, 1)
+	var retryErr *hackernews.RetryError
+	is.True(!errors.As(err, &retryErr))
+	var apiErr *hackernews.APIError
+	is.True(errors.As(err, &apiErr))
+}
+
+func TestRateLimited(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.True(errors.Is(err, hackernews.ErrRateLimited))
+	var apiErr *hackernews.APIError
+	is.True(errors.As(err, &apiErr))
+	is.Equal(apiErr.RetryAfter, 2*time.Minute)
+}
+
+func TestRateLimitedRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		after string
+	}{
+		{"seconds", "0"},
+		{"date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+			calls := 0
+			hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls == 1 {
+					w.Header().Set("Retry-After", test.after)
+					http.Error(w, "slow down", http.StatusTooManyRequests)
+					return
+				}
+				fmt.Fprint(w, `{"hits":[]}`)
+			}), hackernews.WithRetry(2, time.Millisecond))
+			_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+			is.NoErr(err)
+			is.Equal(calls, 2)
+		})
+	}
+}
+
+func TestRateLimitedRetryAfterDeadline(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	calls := 0
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}), hackernews.WithRetry(3, time.Millisecond))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.Equal(calls, 1) // waiting an hour would pass the deadline
+	is.True(errors.Is(err, hackernews.ErrRateLimited))
+}
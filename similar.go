@@ -0,0 +1,85 @@
+package hackernews
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// Similar finds other stories that are related to the given story by searching
+// for the significant words in its title. The story itself is never included
+// in the results. At most 999 stories are returned. ErrNoResults is returned
+// when there are no similar stories.
+func (c *Client) Similar(ctx context.Context, story *Story, limit int) ([]*Story, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	// Leave room for the extra result below
+	if limit > maxResultsPerPage-1 {
+		limit = maxResultsPerPage - 1
+	}
+	words := significantWords(story.Title)
+	if len(words) == 0 {
+		return nil, ErrNoResults
+	}
+	result, err := c.searchStories(ctx, &SearchRequest{
+		Query: strings.Join(words, " "),
+		Tags:  "story",
+		// Ask for one extra in case the story itself is in the results
+		ResultsPerPage: limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	stories := make([]*Story, 0, limit)
+	for _, similar := range result.Stories {
+		if similar.ID == story.ID {
+			continue
+		}
+		stories = append(stories, similar)
+		if len(stories) == limit {
+			break
+		}
+	}
+	if len(stories) == 0 {
+		return nil, ErrNoResults
+	}
+	return stories, nil
+}
+
+// significantWords lowercases the title and removes punctuation and stop-words
+// (including HN prefixes like "Show HN").
+func significantWords(title string) (words []string) {
+	seen := map[string]bool{}
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '+' && r != '#'
+	})
+	for _, word := range fields {
+		if len(word) < 2 || stopWords[word] || seen[word] {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+	return words
+}
+
+var stopWords = map[string]bool{
+	"a": true, "about": true, "after": true, "all": true, "am": true, "an": true,
+	"and": true, "any": true, "are": true, "as": true, "ask": true, "at": true,
+	"be": true, "been": true, "before": true, "being": true, "but": true,
+	"by": true, "can": true, "could": true, "did": true, "do": true,
+	"does": true, "for": true, "from": true, "had": true, "has": true,
+	"have": true, "hn": true, "how": true, "i": true, "if": true, "in": true,
+	"into": true, "is": true, "it": true, "its": true, "just": true,
+	"me": true, "more": true, "most": true, "my": true, "new": true,
+	"no": true, "not": true, "now": true, "of": true, "on": true, "or": true,
+	"our": true, "out": true, "over": true, "show": true, "so": true,
+	"some": true, "than": true, "that": true, "the": true, "their": true,
+	"them": true, "then": true, "there": true, "these": true, "they": true,
+	"this": true, "to": true, "tell": true, "up": true, "us": true,
+	"using": true, "vs": true, "was": true, "we": true, "were": true,
+	"what": true, "when": true, "where": true, "which": true, "who": true,
+	"why": true, "will": true, "with": true, "would": true, "you": true,
+	"your": true,
+}
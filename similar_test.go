@@ -0,0 +1,84 @@
+package hackernews_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestSimilar(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var query string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("query")
+		fmt.Fprint(w, `{"hits":[
+			{"objectID":"2","title":"Rust in the Linux kernel"},
+			{"objectID":"1","title":"Show HN: Rust in the Linux kernel"},
+			{"objectID":"3","title":"Linux kernel drops Rust"}
+		]}`)
+	}))
+	story := &hackernews.Story{ID: 1, Title: "Show HN: Rust in the Linux kernel"}
+	similar, err := hn.Similar(ctx, story, 5)
+	is.NoErr(err)
+	is.Equal(query, "rust linux kernel") // stop-words removed
+	is.Equal(len(similar), 2)
+	for _, s := range similar {
+		is.True(s.ID != story.ID) // source story is excluded
+	}
+}
+
+func TestSimilarLimit(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.URL.Query().Get("hitsPerPage"), "2")
+		fmt.Fprint(w, `{"hits":[{"objectID":"2"},{"objectID":"3"}]}`)
+	}))
+	similar, err := hn.Similar(ctx, &hackernews.Story{ID: 1, Title: "Go generics"}, 1)
+	is.NoErr(err)
+	is.Equal(len(similar), 1)
+	is.Equal(similar[0].ID, 2)
+}
+
+func TestSimilarMaxLimit(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var perPage string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage = r.URL.Query().Get("hitsPerPage")
+		fmt.Fprint(w, `{"hits":[{"objectID":"2"}]}`)
+	}))
+	similar, err := hn.Similar(ctx, &hackernews.Story{ID: 1, Title: "Go generics"}, 1000)
+	is.NoErr(err) // clamped instead of failing validation
+	is.Equal(perPage, "1000")
+	is.Equal(len(similar), 1)
+}
+
+func TestSimilarNoResults(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"hits":[{"objectID":"1","title":"Go generics"}]}`)
+	}))
+	_, err := hn.Similar(ctx, &hackernews.Story{ID: 1, Title: "Go generics"}, 5)
+	is.True(errors.Is(err, hackernews.ErrNoResults)) // only the story itself matched
+	_, err = hn.Similar(ctx, &hackernews.Story{ID: 1, Title: "Ask HN: What is this?"}, 5)
+	is.True(errors.Is(err, hackernews.ErrNoResults)) // nothing significant to search for
+}
+
+func TestSearchNoResultsIsEmpty(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"hits":[]}`)
+	}))
+	result, err := hn.Search(ctx, &hackernews.SearchRequest{Query: "zzzzzz"})
+	is.NoErr(err) // list methods don't return ErrNoResults
+	is.Equal(len(result.Stories), 0)
+}
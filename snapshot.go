@@ -0,0 +1,48 @@
+package hackernews
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// snapshotVersion is the schema version of the snapshots written by
+// SaveSnapshot. Bump it whenever Story changes in a way that older snapshots
+// can't be loaded.
+const snapshotVersion = 1
+
+// snapshot is the format stories are saved in
+type snapshot struct {
+	SchemaVersion int      `json:"schemaVersion"`
+	Stories       []*Story `json:"stories"`
+}
+
+// SaveSnapshot writes the stories to w as JSON, tagged with the schema
+// version, so they can be cached and loaded later with LoadSnapshot.
+func SaveSnapshot(w io.Writer, stories []*Story) error {
+	return json.NewEncoder(w).Encode(snapshot{
+		SchemaVersion: snapshotVersion,
+		Stories:       stories,
+	})
+}
+
+// LoadSnapshot reads stories written by SaveSnapshot. Snapshots written with a
+// different schema version return an error wrapping ErrSnapshotVersion rather
+// than loading partially decoded stories.
+func LoadSnapshot(r io.Reader) ([]*Story, error) {
+	var header struct {
+		SchemaVersion int             `json:"schemaVersion"`
+		Stories       json.RawMessage `json:"stories"`
+	}
+	if err := json.NewDecoder(r).Decode(&header); err != nil {
+		return nil, fmt.Errorf("hackernews: unable to read snapshot: %w", err)
+	}
+	if header.SchemaVersion != snapshotVersion {
+		return nil, fmt.Errorf("%w: snapshot has version %d, expected %d", ErrSnapshotVersion, header.SchemaVersion, snapshotVersion)
+	}
+	var stories []*Story
+	if err := json.Unmarshal(header.Stories, &stories); err != nil {
+		return nil, fmt.Errorf("hackernews: unable to read snapshot stories: %w", err)
+	}
+	return stories, nil
+}
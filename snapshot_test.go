@@ -0,0 +1,36 @@
+package hackernews_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestSnapshot(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	buf := new(bytes.Buffer)
+	is.NoErr(hackernews.SaveSnapshot(buf, []*hackernews.Story{story}))
+	is.True(strings.Contains(buf.String(), `"schemaVersion":1`))
+	stories, err := hackernews.LoadSnapshot(buf)
+	is.NoErr(err)
+	is.Equal(len(stories), 1)
+	is.Equal(stories[0].ID, story.ID)
+	is.Equal(stories[0].Title, story.Title)
+	is.Equal(len(stories[0].Children), len(story.Children))
+}
+
+func TestLoadSnapshotVersionMismatch(t *testing.T) {
+	is := is.New(t)
+	_, err := hackernews.LoadSnapshot(strings.NewReader(`{"schemaVersion":99,"stories":[{"id":1}]}`))
+	is.True(errors.Is(err, hackernews.ErrSnapshotVersion))
+	is.True(strings.Contains(err.Error(), "version 99"))
+	_, err = hackernews.LoadSnapshot(strings.NewReader(`[{"id":1}]`))
+	is.True(err != nil) // not a snapshot
+	_, err = hackernews.LoadSnapshot(strings.NewReader(`{"stories":[{"id":1}]}`))
+	is.True(errors.Is(err, hackernews.ErrSnapshotVersion)) // unversioned
+}
@@ -0,0 +1,83 @@
+package hackernews
+
+import (
+	"sort"
+	"time"
+)
+
+// FilterNewerThan returns the stories that were created after t. The input
+// slice isn't modified.
+func FilterNewerThan(stories []*Story, t time.Time) []*Story {
+	newer := []*Story{}
+	for _, story := range stories {
+		if story.createdAt().After(t) {
+			newer = append(newer, story)
+		}
+	}
+	return newer
+}
+
+// MergeStories concatenates the sets of stories, dropping stories whose ID was
+// already seen so the first occurrence wins. The input slices aren't modified.
+func MergeStories(sets ...[]*Story) []*Story {
+	merged := []*Story{}
+	seen := map[int]bool{}
+	for _, stories := range sets {
+		for _, story := range stories {
+			if seen[story.ID] {
+				continue
+			}
+			seen[story.ID] = true
+			merged = append(merged, story)
+		}
+	}
+	return merged
+}
+
+// SortByPoints sorts the stories in place, most points first. Stories with the
+// same number of points keep their order.
+func SortByPoints(stories []*Story) {
+	sort.SliceStable(stories, func(i, j int) bool {
+		return stories[i].Points > stories[j].Points
+	})
+}
+
+// SortByComments sorts the stories in place, most comments first. Stories
+// without a comment count are treated as having none. Ties keep their order.
+func SortByComments(stories []*Story) {
+	sort.SliceStable(stories, func(i, j int) bool {
+		return numComments(stories[i]) > numComments(stories[j])
+	})
+}
+
+// SortByDate sorts the stories in place, newest first. Ties keep their order.
+func SortByDate(stories []*Story) {
+	sort.SliceStable(stories, func(i, j int) bool {
+		return stories[i].createdAt().After(stories[j].createdAt())
+	})
+}
+
+// SortByRelevancy sorts the stories in place, highest RelevancyScore first.
+// Stories without a score, like the ones from SearchRecent, go last. Ties keep
+// their order.
+func SortByRelevancy(stories []*Story) {
+	sort.SliceStable(stories, func(i, j int) bool {
+		a, b := stories[i].RelevancyScore, stories[j].RelevancyScore
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a > *b
+	})
+}
+
+// GroupByDomain groups the stories by their Domain, keeping them in order
+// within each group. Stories without a URL, like Ask HN posts, are grouped
+// under "". The input slice isn't modified.
+func GroupByDomain(stories []*Story) map[string][]*Story {
+	groups := map[string][]*Story{}
+	for _, story := range stories {
+		domain := story.Domain()
+		groups[domain] = append(groups[domain], story)
+	}
+	return groups
+}
@@ -0,0 +1,88 @@
+package hackernews_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestFilterNewerThan(t *testing.T) {
+	is := is.New(t)
+	boundary := time.Date(2024, 9, 9, 12, 0, 0, 0, time.UTC)
+	stories := []*hackernews.Story{
+		{ID: 1, CreatedAtI: int(boundary.Add(-time.Hour).Unix())},
+		{ID: 2, CreatedAtI: int(boundary.Unix())},
+		{ID: 3, CreatedAtI: int(boundary.Add(time.Second).Unix())},
+		{ID: 4, CreatedAt: boundary.Add(time.Hour)}, // only the parsed date
+		{ID: 5, CreatedAt: boundary.Add(-time.Hour)},
+	}
+	newer := hackernews.FilterNewerThan(stories, boundary)
+	is.Equal(len(newer), 2)
+	is.Equal(newer[0].ID, 3)
+	is.Equal(newer[1].ID, 4)
+	is.Equal(len(stories), 5) // input is untouched
+}
+
+func TestMergeStories(t *testing.T) {
+	is := is.New(t)
+	golang := []*hackernews.Story{{ID: 1, Title: "go"}, {ID: 2}}
+	rust := []*hackernews.Story{{ID: 3}, {ID: 1, Title: "rust"}, {ID: 4}, {ID: 3}}
+	merged := hackernews.MergeStories(golang, nil, rust)
+	is.Equal(len(merged), 4)
+	is.Equal(merged[0].Title, "go") // first occurrence wins
+	is.Equal(merged[1].ID, 2)
+	is.Equal(merged[2].ID, 3)
+	is.Equal(merged[3].ID, 4)
+	is.Equal(len(rust), 4) // input is untouched
+	is.Equal(len(hackernews.MergeStories()), 0)
+}
+
+func TestSortStories(t *testing.T) {
+	is := is.New(t)
+	comments := func(n int) *int { return &n }
+	stories := func() []*hackernews.Story {
+		return []*hackernews.Story{
+			{ID: 1, Points: 10, NumComments: comments(5), CreatedAtI: 1700000100, RelevancyScore: comments(800)},
+			{ID: 2, Points: 50, CreatedAtI: 1700000300},
+			{ID: 3, Points: 10, NumComments: comments(20), CreatedAt: time.Unix(1700000400, 0), RelevancyScore: comments(900)},
+			{ID: 4, Points: 30, NumComments: comments(0), CreatedAtI: 1700000200, RelevancyScore: comments(800)},
+		}
+	}
+	ids := func(stories []*hackernews.Story) (ids []int) {
+		for _, story := range stories {
+			ids = append(ids, story.ID)
+		}
+		return ids
+	}
+	byPoints := stories()
+	hackernews.SortByPoints(byPoints)
+	is.Equal(ids(byPoints), []int{2, 4, 1, 3}) // ties keep their order
+	byComments := stories()
+	hackernews.SortByComments(byComments)
+	is.Equal(ids(byComments), []int{3, 1, 2, 4}) // nil counts as zero
+	byDate := stories()
+	hackernews.SortByDate(byDate)
+	is.Equal(ids(byDate), []int{3, 2, 4, 1})
+	byRelevancy := stories()
+	hackernews.SortByRelevancy(byRelevancy)
+	is.Equal(ids(byRelevancy), []int{3, 1, 4, 2}) // nil scores go last
+}
+
+func TestGroupByDomain(t *testing.T) {
+	is := is.New(t)
+	stories := []*hackernews.Story{
+		{ID: 1, URL: "https://github.com/a/b"},
+		{ID: 2, Title: "Ask HN: Anything?"},
+		{ID: 3, URL: "https://www.github.com/c/d"},
+		{ID: 4, URL: "https://example.com/post"},
+	}
+	groups := hackernews.GroupByDomain(stories)
+	is.Equal(len(groups), 3)
+	is.Equal(groups["github.com"], []*hackernews.Story{stories[0], stories[2]})
+	is.Equal(groups["example.com"], []*hackernews.Story{stories[3]})
+	is.Equal(groups[""], []*hackernews.Story{stories[1]}) // no URL
+	is.Equal(stories[1].ID, 2)                            // input is untouched
+	is.Equal(len(hackernews.GroupByDomain(nil)), 0)
+}
@@ -0,0 +1,33 @@
+package hackernews
+
+import "context"
+
+// Stream pages through the search results in the background, sending each
+// story as its page arrives. Both channels are closed once the results are
+// exhausted, an error occurs or the context is done. At most one error is
+// sent, and the error channel is buffered so it never blocks.
+func (c *Client) Stream(ctx context.Context, req *SearchRequest) (<-chan *Story, <-chan error) {
+	stories := make(chan *Story)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(stories)
+		err := c.eachPage(ctx, c.searchStories, req, func(result *SearchResponse) (bool, error) {
+			for _, story := range result.Stories {
+				if err := ctx.Err(); err != nil {
+					return false, err
+				}
+				select {
+				case stories <- story:
+				case <-ctx.Done():
+					return false, ctx.Err()
+				}
+			}
+			return true, nil
+		})
+		if err != nil {
+			errc <- err
+		}
+	}()
+	return stories, errc
+}
@@ -0,0 +1,52 @@
+package hackernews_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestStream(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, pagedHandler(t, 3, 2))
+	stories, errc := hn.Stream(ctx, &hackernews.SearchRequest{})
+	ids := []int{}
+	for story := range stories {
+		ids = append(ids, story.ID)
+	}
+	is.NoErr(<-errc)
+	is.Equal(ids, []int{1, 2, 3, 4, 5, 6})
+}
+
+func TestStreamError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusInternalServerError)
+	}))
+	stories, errc := hn.Stream(ctx, &hackernews.SearchRequest{})
+	for range stories {
+		t.Fatal("expected no stories")
+	}
+	var apiErr *hackernews.APIError
+	is.True(errors.As(<-errc, &apiErr))
+}
+
+func TestStreamCanceled(t *testing.T) {
+	is := is.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	hn := fakeClient(t, pagedHandler(t, 3, 2))
+	stories, errc := hn.Stream(ctx, &hackernews.SearchRequest{})
+	story := <-stories
+	is.Equal(story.ID, 1)
+	// Stop reading. The producer shouldn't block forever.
+	cancel()
+	for range stories {
+	}
+	is.True(errors.Is(<-errc, context.Canceled))
+}
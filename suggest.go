@@ -0,0 +1,30 @@
+package hackernews
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// SearchOrSuggest searches like Search, but lets Algolia drop words from a
+// query that doesn't match anything. When that happens, the results are for
+// the shorter query and it's returned as a suggestion (e.g. "Did you mean
+// ...?"). Otherwise the suggestion is empty.
+func (c *Client) SearchOrSuggest(ctx context.Context, req *SearchRequest) (*SearchResponse, string, error) {
+	search := *req
+	if search.RemoveWordsIfNoResults == "" {
+		search.RemoveWordsIfNoResults = "lastWords"
+	}
+	result, err := c.searchStories(ctx, &search)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, result.Suggestion, nil
+}
+
+var removedWords = regexp.MustCompile(`<em>[^<]*</em>`)
+
+// suggestion removes the words Algolia dropped from the query
+func suggestion(queryAfterRemoval string) string {
+	return strings.Join(strings.Fields(removedWords.ReplaceAllString(queryAfterRemoval, "")), " ")
+}
@@ -0,0 +1,44 @@
+package hackernews_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestSearchOrSuggest(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var removeWords string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		removeWords = r.URL.Query().Get("removeWordsIfNoResults")
+		fmt.Fprint(w, `{
+			"hits":[{"objectID":"1","title":"Async Rust"}],
+			"nbHits":1,
+			"query":"async rust zzyzx",
+			"queryAfterRemoval":"async rust <em>zzyzx</em>"
+		}`)
+	}))
+	result, suggestion, err := hn.SearchOrSuggest(ctx, &hackernews.SearchRequest{Query: "async rust zzyzx"})
+	is.NoErr(err)
+	is.Equal(removeWords, "lastWords")
+	is.Equal(suggestion, "async rust")
+	is.Equal(result.Suggestion, "async rust")
+	is.Equal(len(result.Stories), 1)
+}
+
+func TestSearchOrSuggestNoSuggestion(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"hits":[{"objectID":"1"}],"nbHits":100,"query":"rust"}`)
+	}))
+	result, suggestion, err := hn.SearchOrSuggest(ctx, &hackernews.SearchRequest{Query: "rust"})
+	is.NoErr(err)
+	is.Equal(suggestion, "")
+	is.Equal(result.NumResults, 100)
+}
@@ -0,0 +1,151 @@
+package hackernews
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// staticTags are the tags Algolia supports that don't take an argument
+var staticTags = map[string]bool{
+	"story":      true,
+	"comment":    true,
+	"poll":       true,
+	"pollopt":    true,
+	"show_hn":    true,
+	"ask_hn":     true,
+	"front_page": true,
+	"job":        true,
+}
+
+var (
+	authorTag = regexp.MustCompile(`^author_[A-Za-z0-9_-]+$`)
+	storyTag  = regexp.MustCompile(`^story_[0-9]+$`)
+)
+
+// validTag checks that the tag is a single tag Algolia understands
+func validTag(tag string) error {
+	if staticTags[tag] || authorTag.MatchString(tag) || storyTag.MatchString(tag) {
+		return nil
+	}
+	return fmt.Errorf("hackernews: invalid tag %q", tag)
+}
+
+// ByTag searches for the given page of results with a single tag. Besides the
+// static tags like "show_hn", the dynamic "author_:USERNAME" and "story_:ID"
+// tags are supported too.
+func (c *Client) ByTag(ctx context.Context, tag string, page int) (*SearchResponse, error) {
+	if err := validTag(tag); err != nil {
+		return nil, err
+	}
+	return c.searchStories(ctx, &SearchRequest{
+		Tags: tag,
+		Page: page,
+	})
+}
+
+// ByAuthor gets the most recent stories submitted by the user. Usernames can
+// only contain letters, digits, dashes and underscores.
+func (c *Client) ByAuthor(ctx context.Context, username string) ([]*Story, error) {
+	tag := "author_" + username
+	if !authorTag.MatchString(tag) {
+		return nil, fmt.Errorf("hackernews: invalid username %q", username)
+	}
+	result, err := c.searchRecentStories(ctx, &SearchRequest{
+		Tags:           tag + ",story",
+		ResultsPerPage: c.perPage(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Stories, nil
+}
+
+// Tag is a single search tag, like "story" or "author_pg"
+type Tag string
+
+// The tags Algolia supports that don't take an argument
+const (
+	StoryTag     Tag = "story"
+	CommentTag   Tag = "comment"
+	PollTag      Tag = "poll"
+	PollOptTag   Tag = "pollopt"
+	ShowHNTag    Tag = "show_hn"
+	AskHNTag     Tag = "ask_hn"
+	FrontPageTag Tag = "front_page"
+	JobTag       Tag = "job"
+)
+
+// AuthorTag matches items submitted by the user
+func AuthorTag(username string) Tag {
+	return Tag("author_" + username)
+}
+
+// StoryIDTag matches the story with the given id and its comments
+func StoryIDTag(id int) Tag {
+	return Tag("story_" + strconv.Itoa(id))
+}
+
+// Tags builds the Tags of a search request. Each call adds a filter that's
+// ANDed with the rest. For example,
+//
+//	hackernews.Tags{}.Author("pg").Or(hackernews.StoryTag, hackernews.PollTag).String()
+//
+// is "author_pg,(story,poll)".
+type Tags []string
+
+// Story matches stories
+func (t Tags) Story() Tags { return t.And(StoryTag) }
+
+// Comment matches comments
+func (t Tags) Comment() Tags { return t.And(CommentTag) }
+
+// Poll matches polls
+func (t Tags) Poll() Tags { return t.And(PollTag) }
+
+// ShowHN matches Show HN posts
+func (t Tags) ShowHN() Tags { return t.And(ShowHNTag) }
+
+// AskHN matches Ask HN posts
+func (t Tags) AskHN() Tags { return t.And(AskHNTag) }
+
+// FrontPage matches the stories on the front page
+func (t Tags) FrontPage() Tags { return t.And(FrontPageTag) }
+
+// Author matches items submitted by the user
+func (t Tags) Author(username string) Tags { return t.And(AuthorTag(username)) }
+
+// StoryID matches the story with the given id and its comments
+func (t Tags) StoryID(id int) Tags { return t.And(StoryIDTag(id)) }
+
+// And requires every one of the tags
+func (t Tags) And(tags ...Tag) Tags {
+	out := append(Tags{}, t...)
+	for _, tag := range tags {
+		out = append(out, string(tag))
+	}
+	return out
+}
+
+// Or requires at least one of the tags. Algolia doesn't support nesting, so
+// each alternative is a single tag.
+func (t Tags) Or(tags ...Tag) Tags {
+	switch len(tags) {
+	case 0:
+		return t
+	case 1:
+		return t.And(tags[0])
+	}
+	alternatives := make([]string, len(tags))
+	for i, tag := range tags {
+		alternatives[i] = string(tag)
+	}
+	return append(append(Tags{}, t...), "("+strings.Join(alternatives, ",")+")")
+}
+
+// String formats the tags for SearchRequest.Tags
+func (t Tags) String() string {
+	return strings.Join(t, ",")
+}
@@ -0,0 +1,116 @@
+package hackernews_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestByTag(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var tags, page string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tags = r.URL.Query().Get("tags")
+		page = r.URL.Query().Get("page")
+		fmt.Fprint(w, `{"hits":[{"objectID":"1"}],"page":1,"nbPages":3}`)
+	}))
+	result, err := hn.ByTag(ctx, "show_hn", 2)
+	is.NoErr(err)
+	is.Equal(tags, "show_hn")
+	is.Equal(page, "1") // pages start at 1
+	is.Equal(result.Page, 2)
+	is.Equal(len(result.Stories), 1)
+}
+
+func TestByTagDynamic(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var tags []string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tags = append(tags, r.URL.Query().Get("tags"))
+		fmt.Fprint(w, `{"hits":[]}`)
+	}))
+	_, err := hn.ByTag(ctx, "author_some-user_1", 0)
+	is.NoErr(err)
+	_, err = hn.ByTag(ctx, "story_8863", 0)
+	is.NoErr(err)
+	is.Equal(tags, []string{"author_some-user_1", "story_8863"})
+}
+
+func TestByTagInvalid(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("invalid tags shouldn't be sent")
+	}))
+	for _, tag := range []string{"", "stories", "story,poll", "author_", "story_abc", "(story)"} {
+		_, err := hn.ByTag(ctx, tag, 0)
+		is.True(err != nil) // invalid tag
+	}
+}
+
+func TestByAuthor(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	var path, tags string
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		tags = r.URL.Query().Get("tags")
+		fmt.Fprint(w, `{"hits":[{"objectID":"1","author":"some_user-1"}]}`)
+	}))
+	stories, err := hn.ByAuthor(ctx, "some_user-1")
+	is.NoErr(err)
+	is.Equal(path, "/api/v1/search_by_date") // most recent first
+	is.Equal(tags, "author_some_user-1,story")
+	is.Equal(len(stories), 1)
+	is.Equal(stories[0].Author, "some_user-1")
+}
+
+func TestByAuthorInvalid(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("invalid usernames shouldn't be sent")
+	}))
+	for _, username := range []string{"", "pg,story", "(pg)", "p g"} {
+		_, err := hn.ByAuthor(ctx, username)
+		is.True(err != nil) // invalid username
+	}
+}
+
+func TestTags(t *testing.T) {
+	tests := []struct {
+		tags hackernews.Tags
+		want string
+	}{
+		{hackernews.Tags{}, ""},
+		{hackernews.Tags{}.Story(), "story"},
+		{hackernews.Tags{}.Comment().Author("pg"), "comment,author_pg"},
+		{hackernews.Tags{}.Author("pg").Or(hackernews.StoryTag, hackernews.PollTag), "author_pg,(story,poll)"},
+		{hackernews.Tags{}.Comment().StoryID(8863), "comment,story_8863"},
+		{hackernews.Tags{}.Or(hackernews.ShowHNTag), "show_hn"},
+		{hackernews.Tags{}.FrontPage().Or(), "front_page"},
+		{hackernews.Tags{}.AskHN().And(hackernews.AuthorTag("dang"), hackernews.PollTag), "ask_hn,author_dang,poll"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(test.tags.String(), test.want)
+		})
+	}
+}
+
+func TestTagsImmutable(t *testing.T) {
+	is := is.New(t)
+	base := make(hackernews.Tags, 0, 10).Story()
+	a := base.Author("a")
+	b := base.Author("b")
+	is.Equal(a.String(), "story,author_a")
+	is.Equal(b.String(), "story,author_b")
+	is.Equal(base.String(), "story")
+}
@@ -0,0 +1,122 @@
+package hackernews
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	paragraphTag = regexp.MustCompile(`(?i)<p\s*/?>|</p>|<br\s*/?>`)
+	anyTag       = regexp.MustCompile(`<[^>]*>`)
+	blankLines   = regexp.MustCompile(`\n{3,}`)
+)
+
+// plainText converts the HTML that HN uses for story and comment bodies into
+// plain text. Paragraphs become blank lines, tags are stripped and entities
+// are decoded.
+func plainText(body string) string {
+	text := paragraphTag.ReplaceAllString(body, "\n\n")
+	text = anyTag.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = blankLines.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// PlainText returns the story's text with the HTML tags stripped and entities
+// decoded. Paragraphs are separated by a blank line. Text is left untouched.
+func (s *Story) PlainText() string {
+	if s.Text == nil {
+		return ""
+	}
+	return plainText(*s.Text)
+}
+
+// PlainText returns the comment's text as plain text. See Story.PlainText.
+func (c *Children) PlainText() string {
+	if c.Text == nil {
+		return ""
+	}
+	return plainText(*c.Text)
+}
+
+// decodeChildrenText replaces each comment's HTML with plain text. Like
+// filterChildren, it walks the tree with an explicit stack.
+func decodeChildrenText(children []Children) {
+	stack := [][]Children{children}
+	for len(stack) > 0 {
+		level := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for i := range level {
+			if level[i].Text != nil {
+				text := plainText(*level[i].Text)
+				level[i].Text = &text
+			}
+			stack = append(stack, level[i].Children)
+		}
+	}
+}
+
+// Summary returns the story's text as a single line of plain text, shortened to
+// at most maxChars characters. Longer text is cut at a word boundary and ends
+// with an ellipsis. Stories without text return an empty string.
+func (s *Story) Summary(maxChars int) string {
+	if s.Text == nil || maxChars <= 0 {
+		return ""
+	}
+	text := strings.Join(strings.Fields(plainText(*s.Text)), " ")
+	return truncate(text, maxChars)
+}
+
+// maxStringText is the most of a comment's text that Children.String includes
+const maxStringText = 60
+
+// String describes the story on a single line, like
+// "1 Y Combinator (57p, 18 comments) by pg".
+func (s *Story) String() string {
+	if s == nil {
+		return "<nil>"
+	}
+	stats := strconv.Itoa(s.Points) + "p"
+	if s.NumComments != nil {
+		stats += ", " + strconv.Itoa(*s.NumComments) + " comments"
+	}
+	return strconv.Itoa(s.ID) + " " + s.Title + " (" + stats + ") by " + s.Author
+}
+
+// String describes the comment on a single line with its author and the start
+// of its text, like "15 sama: Hey Paul, this is a great…".
+func (c *Children) String() string {
+	if c == nil {
+		return "<nil>"
+	}
+	author := "[deleted]"
+	if c.Author != nil {
+		author = *c.Author
+	}
+	text := strings.Join(strings.Fields(c.PlainText()), " ")
+	return strconv.Itoa(c.ID) + " " + author + ": " + truncate(text, maxStringText)
+}
+
+// truncate shortens text to at most max characters, including the ellipsis
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	cut := runes[:max-1]
+	if i := lastSpace(cut); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(string(cut), " .,;:") + "…"
+}
+
+func lastSpace(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,73 @@
+package hackernews_test
+
+import (
+	"fmt"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestSummaryShort(t *testing.T) {
+	is := is.New(t)
+	text := "<p>What&#x27;s your <i>favorite</i> editor?"
+	story := &hackernews.Story{Text: &text}
+	is.Equal(story.Summary(100), "What's your favorite editor?")
+}
+
+func TestSummaryLong(t *testing.T) {
+	is := is.New(t)
+	text := "I&#x27;ve been building a small database in Go.<p>It supports transactions."
+	story := &hackernews.Story{Text: &text}
+	summary := story.Summary(30)
+	is.Equal(summary, "I've been building a small…")
+	is.True(utf8.RuneCountInString(summary) <= 30)
+}
+
+func TestSummaryMultibyte(t *testing.T) {
+	is := is.New(t)
+	text := "日本語のテキストはスペースがありません。とても長い文章です。"
+	story := &hackernews.Story{Text: &text}
+	summary := story.Summary(10)
+	is.True(utf8.ValidString(summary)) // never splits a character
+	is.Equal(utf8.RuneCountInString(summary), 10)
+	is.Equal(summary, "日本語のテキストは…")
+}
+
+func TestSummaryNil(t *testing.T) {
+	is := is.New(t)
+	story := &hackernews.Story{}
+	is.Equal(story.Summary(100), "")
+}
+
+func TestPlainText(t *testing.T) {
+	is := is.New(t)
+	text := "Isn&#x27;t it <i>great</i> &gt; 1?<p>Second <a href=\"https:&#x2F;&#x2F;example.com\">paragraph</a>."
+	story := &hackernews.Story{Text: &text}
+	is.Equal(story.PlainText(), "Isn't it great > 1?\n\nSecond paragraph.")
+	is.Equal(*story.Text, text) // raw HTML is kept
+	comment := &hackernews.Children{Text: &text}
+	is.Equal(comment.PlainText(), "Isn't it great > 1?\n\nSecond paragraph.")
+	is.Equal((&hackernews.Story{}).PlainText(), "")
+	is.Equal((&hackernews.Children{}).PlainText(), "")
+}
+
+func TestStoryString(t *testing.T) {
+	is := is.New(t)
+	comments := 18
+	story := &hackernews.Story{ID: 1, Title: "Y Combinator", Points: 57, NumComments: &comments, Author: "pg"}
+	is.Equal(fmt.Sprint(story), "1 Y Combinator (57p, 18 comments) by pg")
+	is.Equal((&hackernews.Story{ID: 2, Title: "Untitled"}).String(), "2 Untitled (0p) by ")
+	var missing *hackernews.Story
+	is.Equal(missing.String(), "<nil>")
+}
+
+func TestChildrenString(t *testing.T) {
+	is := is.New(t)
+	author := "sama"
+	text := "<p>Hey Paul, this is a great idea.<p>I think it would be even better with a longer comment than this."
+	comment := &hackernews.Children{ID: 15, Author: &author, Text: &text}
+	is.Equal(fmt.Sprint(comment), "15 sama: Hey Paul, this is a great idea. I think it would be even…")
+	is.Equal((&hackernews.Children{ID: 16}).String(), "16 [deleted]: ")
+}
@@ -0,0 +1,161 @@
+package hackernews
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// timeFormats are the created_at formats we understand. Algolia uses RFC3339,
+// but mirrors and cached data sometimes differ slightly.
+var timeFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// parseTime parses created_at, falling back to the created_at_i timestamp when
+// created_at is missing or can't be parsed
+func parseTime(raw json.RawMessage, unix int) time.Time {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '"' {
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil {
+			for _, format := range timeFormats {
+				if t, err := time.Parse(format, value); err == nil {
+					return t
+				}
+			}
+		}
+	} else if seconds, err := strconv.ParseInt(string(raw), 10, 64); err == nil && seconds > 0 {
+		return time.Unix(seconds, 0).UTC()
+	}
+	if unix > 0 {
+		return time.Unix(int64(unix), 0).UTC()
+	}
+	return time.Time{}
+}
+
+// UnmarshalJSON tolerates created_at dates in unexpected formats
+func (s *Story) UnmarshalJSON(data []byte) error {
+	type story Story
+	aux := struct {
+		*story
+		CreatedAt json.RawMessage `json:"created_at"`
+	}{story: (*story)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	s.CreatedAt = parseTime(aux.CreatedAt, s.CreatedAtI)
+	return nil
+}
+
+// UnmarshalJSON tolerates created_at dates in unexpected formats
+func (c *Children) UnmarshalJSON(data []byte) error {
+	type children Children
+	aux := struct {
+		*children
+		CreatedAt json.RawMessage `json:"created_at"`
+	}{children: (*children)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	c.CreatedAt = parseTime(aux.CreatedAt, c.CreatedAtI)
+	return nil
+}
+
+// UnmarshalJSON tolerates created_at dates in unexpected formats
+func (h *Hit) UnmarshalJSON(data []byte) error {
+	type hit Hit
+	aux := struct {
+		*hit
+		CreatedAt json.RawMessage `json:"created_at"`
+	}{hit: (*hit)(h)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	h.CreatedAt = parseTime(aux.CreatedAt, h.CreatedAtI)
+	return nil
+}
+
+// Age is how long ago the story was created. It's 0 when the creation time is
+// unknown.
+func (s *Story) Age() time.Duration {
+	return s.AgeAt(time.Now())
+}
+
+// AgeAt is how long before now the story was created, for a fixed clock.
+func (s *Story) AgeAt(now time.Time) time.Duration {
+	return age(s.createdAt(), now)
+}
+
+// RelativeTime formats the story's age the way HN does, but shorter: "now",
+// "5m", "2h", "3d", "4mo" or "1y". It's empty when the creation time is
+// unknown.
+func (s *Story) RelativeTime() string {
+	return s.RelativeTimeAt(time.Now())
+}
+
+// RelativeTimeAt is RelativeTime for a fixed clock.
+func (s *Story) RelativeTimeAt(now time.Time) string {
+	return relativeTime(s.createdAt(), now)
+}
+
+// Age is how long ago the comment was created. See Story.Age.
+func (c *Children) Age() time.Duration {
+	return c.AgeAt(time.Now())
+}
+
+// AgeAt is how long before now the comment was created, for a fixed clock.
+func (c *Children) AgeAt(now time.Time) time.Duration {
+	return age(c.createdAt(), now)
+}
+
+// RelativeTime formats the comment's age. See Story.RelativeTime.
+func (c *Children) RelativeTime() string {
+	return c.RelativeTimeAt(time.Now())
+}
+
+// RelativeTimeAt is RelativeTime for a fixed clock.
+func (c *Children) RelativeTimeAt(now time.Time) string {
+	return relativeTime(c.createdAt(), now)
+}
+
+// age is the time between created and now. Unknown and future times are 0.
+func age(created, now time.Time) time.Duration {
+	if created.IsZero() || created.After(now) {
+		return 0
+	}
+	return now.Sub(created)
+}
+
+const (
+	day   = 24 * time.Hour
+	month = 30 * day
+	year  = 365 * day
+)
+
+func relativeTime(created, now time.Time) string {
+	if created.IsZero() {
+		return ""
+	}
+	d := age(created, now)
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return strconv.Itoa(int(d/time.Minute)) + "m"
+	case d < day:
+		return strconv.Itoa(int(d/time.Hour)) + "h"
+	case d < month:
+		return strconv.Itoa(int(d/day)) + "d"
+	case d < year:
+		return strconv.Itoa(int(d/month)) + "mo"
+	default:
+		return strconv.Itoa(int(d/year)) + "y"
+	}
+}
@@ -0,0 +1,102 @@
+package hackernews_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestCreatedAtFormats(t *testing.T) {
+	expected := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	tests := []struct {
+		name      string
+		createdAt string
+	}{
+		{"rfc3339", `"2023-11-14T22:13:20Z"`},
+		{"rfc3339 millis", `"2023-11-14T22:13:20.000Z"`},
+		{"rfc3339 offset", `"2023-11-14T23:13:20+01:00"`},
+		{"no zone", `"2023-11-14T22:13:20"`},
+		{"space", `"2023-11-14 22:13:20"`},
+		{"rfc1123", `"Tue, 14 Nov 2023 22:13:20 UTC"`},
+		{"unix", `1700000000`},
+		{"unparseable", `"yesterday"`},
+		{"null", `null`},
+		{"missing", ``},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+			field := ""
+			if test.createdAt != "" {
+				field = fmt.Sprintf(`"created_at":%s,`, test.createdAt)
+			}
+			story := new(hackernews.Story)
+			is.NoErr(json.Unmarshal([]byte(`{"id":1,`+field+`"created_at_i":1700000000,"title":"Story"}`), story))
+			is.True(story.CreatedAt.Equal(expected))
+			is.Equal(story.ID, 1)
+			is.Equal(story.Title, "Story")
+			child := new(hackernews.Children)
+			is.NoErr(json.Unmarshal([]byte(`{"id":2,`+field+`"created_at_i":1700000000,"children":[]}`), child))
+			is.True(child.CreatedAt.Equal(expected))
+			is.Equal(child.ID, 2)
+			hit := new(hackernews.Hit)
+			is.NoErr(json.Unmarshal([]byte(`{"objectID":"3",`+field+`"created_at_i":1700000000}`), hit))
+			is.True(hit.CreatedAt.Equal(expected))
+			is.Equal(hit.ID, "3")
+		})
+	}
+}
+
+func TestCreatedAtNested(t *testing.T) {
+	is := is.New(t)
+	story := new(hackernews.Story)
+	is.NoErr(json.Unmarshal([]byte(`{"id":1,"created_at":"bad","created_at_i":1700000000,"children":[
+		{"id":2,"created_at":"2023-11-14 22:15:00","children":[]}
+	]}`), story))
+	is.Equal(story.CreatedAt.Unix(), int64(1700000000))
+	is.Equal(story.Children[0].CreatedAt.Unix(), int64(1700000100))
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 9, 9, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago      time.Duration
+		expected string
+	}{
+		{0, "now"},
+		{59 * time.Second, "now"},
+		{5 * time.Minute, "5m"},
+		{2*time.Hour + 59*time.Minute, "2h"},
+		{3 * 24 * time.Hour, "3d"},
+		{65 * 24 * time.Hour, "2mo"},
+		{800 * 24 * time.Hour, "2y"},
+		{-time.Hour, "now"}, // clock skew
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			is := is.New(t)
+			created := now.Add(-test.ago)
+			story := &hackernews.Story{CreatedAtI: int(created.Unix())}
+			is.Equal(story.RelativeTimeAt(now), test.expected)
+			comment := &hackernews.Children{CreatedAt: created}
+			is.Equal(comment.RelativeTimeAt(now), test.expected)
+		})
+	}
+}
+
+func TestAge(t *testing.T) {
+	is := is.New(t)
+	now := time.Date(2024, 9, 9, 12, 0, 0, 0, time.UTC)
+	story := &hackernews.Story{CreatedAt: now.Add(-90 * time.Minute)}
+	is.Equal(story.AgeAt(now), 90*time.Minute)
+	is.Equal((&hackernews.Story{}).AgeAt(now), time.Duration(0)) // unknown
+	is.Equal((&hackernews.Story{}).RelativeTimeAt(now), "")
+	is.Equal((&hackernews.Children{}).RelativeTime(), "")
+	recent := &hackernews.Children{CreatedAt: time.Now().Add(-time.Hour)}
+	is.True(recent.Age() >= time.Hour)
+	is.Equal(recent.RelativeTime(), "1h")
+}
@@ -0,0 +1,40 @@
+package hackernews
+
+import (
+	"fmt"
+	"io"
+)
+
+// BytesRead returns the total number of response body bytes the client has
+// read so far.
+func (c *Client) BytesRead() int64 {
+	c.bytesMu.Lock()
+	defer c.bytesMu.Unlock()
+	return c.bytesRead
+}
+
+// checkByteBudget returns an error when the client has read more than its
+// byte budget
+func (c *Client) checkByteBudget() error {
+	if c.byteBudget <= 0 {
+		return nil
+	}
+	if read := c.BytesRead(); read > c.byteBudget {
+		return fmt.Errorf("%w: read %d of %d bytes", ErrByteBudgetExceeded, read, c.byteBudget)
+	}
+	return nil
+}
+
+// countingReader adds the bytes it reads to the client's total
+type countingReader struct {
+	r      io.Reader
+	client *Client
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.client.bytesMu.Lock()
+	cr.client.bytesRead += int64(n)
+	cr.client.bytesMu.Unlock()
+	return n, err
+}
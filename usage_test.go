@@ -0,0 +1,47 @@
+package hackernews_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestBytesRead(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	body := `{"hits":[{"objectID":"1"}]}`
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	is.Equal(hn.BytesRead(), int64(0))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	is.Equal(hn.BytesRead(), int64(len(body)))
+	_, err = hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err)
+	is.Equal(hn.BytesRead(), int64(2*len(body))) // keeps adding up
+}
+
+func TestWithByteBudget(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	body := `{"hits":[{"objectID":"1","title":"` + strings.Repeat("x", 100) + `"}]}`
+	requests := 0
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, body)
+	}), hackernews.WithByteBudget(int64(len(body))+50))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{})
+	is.NoErr(err) // within budget
+	_, err = hn.Search(ctx, &hackernews.SearchRequest{})
+	is.True(errors.Is(err, hackernews.ErrByteBudgetExceeded)) // goes over the budget
+	_, err = hn.Search(ctx, &hackernews.SearchRequest{})
+	is.True(errors.Is(err, hackernews.ErrByteBudgetExceeded))
+	is.Equal(requests, 2) // no more requests once exceeded
+}
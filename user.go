@@ -0,0 +1,61 @@
+package hackernews
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// User is a Hacker News user's profile
+type User struct {
+	Username        string    `json:"username,omitempty"`
+	About           *string   `json:"about,omitempty"`
+	Karma           int       `json:"karma,omitempty"`
+	CreatedAt       time.Time `json:"created_at,omitempty"`
+	CreatedAtI      int       `json:"created_at_i,omitempty"`
+	SubmissionCount int       `json:"submission_count,omitempty"`
+	CommentCount    int       `json:"comment_count,omitempty"`
+}
+
+// GetUser finds a user by their username. It returns an error matching
+// ErrNotFound when there's no such user.
+func (c *Client) GetUser(ctx context.Context, username string) (*User, error) {
+	ctx, cancel := withDefaultTimeout(ctx, c.findTimeout)
+	defer cancel()
+	user := new(User)
+	if err := c.get(ctx, c.baseURL+"/users/"+url.PathEscape(username), user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// AuthorsKarma looks up the karma of each author concurrently. Each author is
+// only looked up once. Authors that fail to load are left out of the map
+// rather than failing the whole batch.
+func (c *Client) AuthorsKarma(ctx context.Context, usernames []string) (map[string]int, error) {
+	var unique []string
+	seen := map[string]bool{}
+	for _, username := range usernames {
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		unique = append(unique, username)
+	}
+	karma := map[string]int{}
+	var mu sync.Mutex
+	c.concurrently(ctx, len(unique), func(i int) {
+		user, err := c.GetUser(ctx, unique[i])
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		karma[unique[i]] = user.Karma
+		mu.Unlock()
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return karma, nil
+}
@@ -0,0 +1,66 @@
+package hackernews_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+// serveUsers serves the user fixtures in testdata/users
+func serveUsers(t testing.TB) (http.Handler, map[string]int) {
+	var mu sync.Mutex
+	hits := map[string]int{}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username := path.Base(r.URL.Path)
+		mu.Lock()
+		hits[username]++
+		mu.Unlock()
+		http.ServeFile(w, r, "testdata/users/"+username+".json")
+	}), hits
+}
+
+func TestGetUser(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	handler, _ := serveUsers(t)
+	hn := fakeClient(t, handler)
+	user, err := hn.GetUser(ctx, "alice")
+	is.NoErr(err)
+	is.Equal(user.Username, "alice")
+	is.Equal(user.Karma, 1234)
+	is.True(user.About != nil)
+	is.True(user.CreatedAt.Equal(time.Date(2010, 1, 2, 3, 4, 5, 0, time.UTC)))
+	is.Equal(user.CreatedAtI, 1262401445)
+	is.Equal(user.SubmissionCount, 250)
+	is.Equal(user.CommentCount, 1800)
+}
+
+func TestGetUserNotFound(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	handler, _ := serveUsers(t)
+	hn := fakeClient(t, handler)
+	_, err := hn.GetUser(ctx, "nobody")
+	is.True(errors.Is(err, hackernews.ErrNotFound))
+}
+
+func TestAuthorsKarma(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	handler, hits := serveUsers(t)
+	hn := fakeClient(t, handler)
+	karma, err := hn.AuthorsKarma(ctx, []string{"alice", "bob", "alice", "missing", "bob"})
+	is.NoErr(err)
+	is.Equal(len(karma), 2) // missing user is omitted
+	is.Equal(karma["alice"], 1234)
+	is.Equal(karma["bob"], 56)
+	is.Equal(hits["alice"], 1) // looked up once
+	is.Equal(hits["bob"], 1)   // looked up once
+}
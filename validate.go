@@ -0,0 +1,110 @@
+package hackernews
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// itemTypes are the types of items on Hacker News
+var itemTypes = map[string]bool{
+	"story":   true,
+	"comment": true,
+	"poll":    true,
+	"pollopt": true,
+	"job":     true,
+}
+
+// Validate checks that the story is well-formed: it has an ID, a known type,
+// a title when it's a story, and every comment in the tree points back at the
+// story and its parent. When the item is a comment, its replies must point
+// back at the comment's StoryID. It returns a descriptive error for the first
+// problem.
+func (s *Story) Validate() error {
+	if s.ID == 0 {
+		return errors.New("hackernews: story is missing an id")
+	}
+	if s.Type != "" && !itemTypes[s.Type] {
+		return fmt.Errorf("hackernews: story %d has unknown type %q", s.ID, s.Type)
+	}
+	if (s.Type == "" || s.Type == "story") && s.Title == "" {
+		return fmt.Errorf("hackernews: story %d is missing a title", s.ID)
+	}
+	storyID := s.ID
+	if s.StoryID != nil && *s.StoryID != 0 {
+		storyID = *s.StoryID
+	}
+	return validateChildren(storyID, s.ID, s.Children)
+}
+
+func validateChildren(storyID, parentID int, children []Children) error {
+	for _, child := range children {
+		if child.ID == 0 {
+			return fmt.Errorf("hackernews: comment under %d is missing an id", parentID)
+		}
+		if child.Type != "" && child.Type != "comment" && child.Type != "pollopt" {
+			return fmt.Errorf("hackernews: comment %d has unexpected type %q", child.ID, child.Type)
+		}
+		if child.StoryID != storyID {
+			return fmt.Errorf("hackernews: comment %d belongs to story %d, not %d", child.ID, child.StoryID, storyID)
+		}
+		if child.ParentID != parentID {
+			return fmt.Errorf("hackernews: comment %d has parent %d, not %d", child.ID, child.ParentID, parentID)
+		}
+		if err := validateChildren(storyID, child.ID, child.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// numericCondition is a single condition in Algolia's numericFilters
+var numericCondition = regexp.MustCompile(`^(points|created_at_i|num_comments)(<|<=|=|!=|>=|>)-?[0-9]+$`)
+
+// Validate checks the search request for mistakes that Algolia would reject,
+// so they fail fast with an error naming the field. Search and SearchRecent
+// call it before sending the request.
+func (s *SearchRequest) Validate() error {
+	if s.Page < 0 {
+		return fmt.Errorf("hackernews: Page can't be negative, got %d", s.Page)
+	}
+	if s.ResultsPerPage < 0 || s.ResultsPerPage > maxResultsPerPage {
+		return fmt.Errorf("hackernews: ResultsPerPage must be between 1 and %d, or 0 for the default, got %d", maxResultsPerPage, s.ResultsPerPage)
+	}
+	for _, attribute := range s.SearchIn {
+		if !searchableAttributes[attribute] {
+			return fmt.Errorf("hackernews: SearchIn has an unknown attribute %q", attribute)
+		}
+	}
+	switch s.TypoTolerance {
+	case "", "true", "false", "min", "strict":
+	default:
+		return fmt.Errorf("hackernews: TypoTolerance must be true, false, min or strict, got %q", s.TypoTolerance)
+	}
+	filters := []struct {
+		field, key, value string
+	}{
+		{"Points", "points", s.Points},
+		{"CreatedAt", "created_at_i", s.CreatedAt},
+		{"NumComments", "num_comments", s.NumComments},
+	}
+	for _, filter := range filters {
+		if filter.value == "" {
+			continue
+		}
+		for _, condition := range strings.Split(injectKey(filter.value, filter.key), ",") {
+			if !strings.HasPrefix(condition, filter.key) || !numericCondition.MatchString(condition) {
+				return fmt.Errorf("hackernews: %s has an invalid condition %q", filter.field, condition)
+			}
+		}
+	}
+	for _, filter := range s.Filters {
+		for _, condition := range strings.Split(string(filter), ",") {
+			if !numericCondition.MatchString(condition) {
+				return fmt.Errorf("hackernews: Filters has an invalid condition %q", condition)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package hackernews_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/matthewmueller/hackernews"
+)
+
+func TestValidate(t *testing.T) {
+	is := is.New(t)
+	story := findFixture(t)
+	is.NoErr(story.Validate())
+	is.NoErr((&hackernews.Story{ID: 1, Title: "From search"}).Validate())
+	is.NoErr((&hackernews.Story{ID: 2, Type: "comment"}).Validate()) // comments don't need titles
+}
+
+func TestValidateComment(t *testing.T) {
+	is := is.New(t)
+	hn := fakeClient(t, serveFile(t, "testdata/comment.json"))
+	comment, err := hn.Find(context.Background(), 101)
+	is.NoErr(err)
+	is.NoErr(comment.Validate()) // replies belong to the comment's story
+	storyID := 100
+	wrong := &hackernews.Story{ID: 101, Type: "comment", StoryID: &storyID, Children: []hackernews.Children{
+		{ID: 103, Type: "comment", StoryID: 101, ParentID: 101},
+	}}
+	is.True(wrong.Validate() != nil) // comment 103 belongs to story 101, not 100
+}
+
+func TestValidateInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		story *hackernews.Story
+		err   string
+	}{
+		{"missing id", &hackernews.Story{Title: "Title"}, "missing an id"},
+		{"unknown type", &hackernews.Story{ID: 1, Type: "tweet", Title: "Title"}, `unknown type "tweet"`},
+		{"missing title", &hackernews.Story{ID: 1, Type: "story"}, "missing a title"},
+		{"wrong story", &hackernews.Story{ID: 1, Title: "Title", Children: []hackernews.Children{
+			{ID: 2, Type: "comment", StoryID: 9, ParentID: 1},
+		}}, "comment 2 belongs to story 9, not 1"},
+		{"wrong parent", &hackernews.Story{ID: 1, Title: "Title", Children: []hackernews.Children{
+			{ID: 2, Type: "comment", StoryID: 1, ParentID: 1, Children: []hackernews.Children{
+				{ID: 3, Type: "comment", StoryID: 1, ParentID: 1},
+			}},
+		}}, "comment 3 has parent 1, not 2"},
+		{"comment type", &hackernews.Story{ID: 1, Title: "Title", Children: []hackernews.Children{
+			{ID: 2, Type: "story", StoryID: 1, ParentID: 1},
+		}}, `comment 2 has unexpected type "story"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+			err := test.story.Validate()
+			is.True(err != nil)
+			is.True(strings.Contains(err.Error(), test.err))
+		})
+	}
+}
+
+func TestSearchRequestResultsPerPage(t *testing.T) {
+	tests := []struct {
+		perPage int
+		valid   bool
+	}{
+		{-1, false},
+		{0, true}, // the default
+		{1, true},
+		{1000, true},
+		{1001, false},
+		{5000, false},
+	}
+	for _, test := range tests {
+		t.Run(strconv.Itoa(test.perPage), func(t *testing.T) {
+			is := is.New(t)
+			search := &hackernews.SearchRequest{ResultsPerPage: test.perPage}
+			err := search.Validate()
+			is.Equal(err == nil, test.valid)
+			if err != nil {
+				is.True(strings.Contains(err.Error(), "ResultsPerPage"))
+			}
+		})
+	}
+}
+
+func TestSearchValidates(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	hn := fakeClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("invalid searches shouldn't be sent")
+	}))
+	_, err := hn.Search(ctx, &hackernews.SearchRequest{ResultsPerPage: 5000})
+	is.True(err != nil)
+	_, err = hn.SearchRecent(ctx, &hackernews.SearchRequest{ResultsPerPage: 5000})
+	is.True(err != nil)
+}
+
+func TestSearchRequestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		search hackernews.SearchRequest
+		field  string
+	}{
+		{"empty", hackernews.SearchRequest{}, ""},
+		{"valid", hackernews.SearchRequest{
+			Page:        2,
+			Points:      "> 500, points <= 1000",
+			CreatedAt:   "created_at_i>1600000000",
+			NumComments: "!= 0",
+			Filters:     []hackernews.NumericFilter{hackernews.PointsBetween(1, 2)},
+		}, ""},
+		{"negative page", hackernews.SearchRequest{Page: -1}, "Page"},
+		{"bad operator", hackernews.SearchRequest{Points: "=> 5"}, "Points"},
+		{"not a number", hackernews.SearchRequest{CreatedAt: "> yesterday"}, "CreatedAt"},
+		{"wrong key", hackernews.SearchRequest{NumComments: "points > 5"}, "NumComments"},
+		{"empty condition", hackernews.SearchRequest{Points: ">5,"}, "Points"},
+		{"bad filter", hackernews.SearchRequest{Filters: []hackernews.NumericFilter{"score>5"}}, "Filters"},
+		{"search in", hackernews.SearchRequest{SearchIn: []string{hackernews.AttributeTitle, hackernews.AttributeURL}}, ""},
+		{"typo tolerance", hackernews.SearchRequest{TypoTolerance: "strict"}, ""},
+		{"bad typo tolerance", hackernews.SearchRequest{TypoTolerance: "loose"}, "TypoTolerance"},
+		{"bad attribute", hackernews.SearchRequest{SearchIn: []string{hackernews.AttributeTitle, "points"}}, "SearchIn"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+			err := test.search.Validate()
+			if test.field == "" {
+				is.NoErr(err)
+				return
+			}
+			is.True(err != nil)
+			is.True(strings.HasPrefix(err.Error(), "hackernews: "+test.field+" ")) // names the field
+		})
+	}
+}